@@ -0,0 +1,64 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+var _ = Describe("Alignment Test Environment", func() {
+	var buf *bytes.Buffer
+	var p ttyprogress.Context
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		p = ttyprogress.For(buf).AlignColumns()
+	})
+
+	It("aligns the visualization of elements with different numbers of decorators", func() {
+		a, err := ttyprogress.NewBar().SetTotal(10).SetWidth(10).PrependMessage("alpha").Add(p)
+		Expect(err).To(Succeed())
+		b, err := ttyprogress.NewBar().SetTotal(10).SetWidth(10).PrependMessage("b").PrependMessage("c").AppendMessage("done").Add(p)
+		Expect(err).To(Succeed())
+		a.Set(5)
+		b.Set(10)
+		a.Close()
+		p.Close()
+		p.Wait(context.Background())
+
+		Expect(screen(buf.String())).To(Equal([]string{
+			"alpha   [=====>----]",
+			"b     c [==========] done",
+		}))
+	})
+
+	It("realigns closed elements", func() {
+		a, err := ttyprogress.NewBar().SetTotal(10).SetWidth(10).PrependMessage("a").Add(p)
+		Expect(err).To(Succeed())
+		b, err := ttyprogress.NewBar().SetTotal(10).SetWidth(10).PrependMessage("b").Add(p)
+		Expect(err).To(Succeed())
+		b.Set(10)
+		Expect(b.IsClosed()).To(BeTrue())
+		c, err := ttyprogress.NewBar().SetTotal(10).SetWidth(10).PrependMessage("longer").Add(p)
+		Expect(err).To(Succeed())
+		a.Set(5)
+		c.Set(5)
+		// closed elements are realigned by the next tick
+		time.Sleep(100 * time.Millisecond)
+		a.Close()
+		c.Close()
+		p.Close()
+		p.Wait(context.Background())
+
+		Expect(screen(buf.String())).To(Equal([]string{
+			"a      [=====>----]",
+			"b      [==========]",
+			"longer [=====>----]",
+		}))
+	})
+})
@@ -28,12 +28,12 @@ func NewAnonymousGroup() *AnonymousGroupDefinition {
 
 func (d *AnonymousGroupDefinition) Dup() *AnonymousGroupDefinition {
 	dup := &AnonymousGroupDefinition{}
-	dup.GroupBaseDefinition = d.GroupBaseDefinition.Dup(specs.NewSelf(d))
+	dup.GroupBaseDefinition = d.GroupBaseDefinition.Dup(specs.NewSelf(dup))
 	return dup
 }
 
 func (d *AnonymousGroupDefinition) Add(c Container) (AnonymousGroup, error) {
-	return newAnonymousGroup(c, d)
+	return newAnonymousGroup(c, specs.InheritTheme(d, c))
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -18,6 +18,9 @@ var (
 
 	// ErrMaxCurrentReached is error when trying to set current value that exceeds the total value
 	ErrMaxCurrentReached = errors.New("errors: current value is greater total value")
+
+	// ErrNotResettable is the error returned by elements not supporting Reset.
+	ErrNotResettable = errors.New("element cannot be reset")
 )
 
 // Bar is a progress bar used to visualize the progress of an action in
@@ -52,11 +55,11 @@ func (d *BarDefinition) GetGroupNotifier() specs.GroupNotifier {
 }
 
 func (d *BarDefinition) Add(c Container) (Bar, error) {
-	return newBar(c, d)
+	return newBar(c, specs.InheritTheme(d, c))
 }
 
 func (d *BarDefinition) AddWithTotal(c Container, total int) (Bar, error) {
-	return newBar(c, d, total)
+	return newBar(c, specs.InheritTheme(d, c), total)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
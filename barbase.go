@@ -1,6 +1,8 @@
 package ttyprogress
 
 import (
+	"sync"
+
 	"github.com/mandelsoft/goutils/generics"
 	"github.com/mandelsoft/object"
 	"github.com/mandelsoft/ttyprogress/ppi"
@@ -8,27 +10,90 @@ import (
 )
 
 type barGroupNotifier struct {
-	started bool
+	lock     sync.Mutex
+	started  bool
+	current  int
+	added    int
+	expected int
 }
 
-var _ specs.GroupNotifier = (*barGroupNotifier)(nil)
+var _ specs.ExpectingGroupNotifier = (*barGroupNotifier)(nil)
+var _ specs.ProgressAggregator = (*barGroupNotifier)(nil)
+
+// groupBar is the interface required for main
+// progress indicators using a barGroupNotifier.
+type groupBar interface {
+	ppi.BarInterface[int]
+	Set(n int) bool
+	Incr() bool
+}
 
 func (n *barGroupNotifier) Add(b ProgressElement, p any) {
-	eff := b.(*IntBarBase[IntBarImpl])
-	defer eff.elem.Lock()()
+	eff := b.(groupBar)
 
-	if !n.started {
-		eff.elem.Protected().SetTotal(0)
-		n.started = true
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.added++
+	eff.SetTotal(max(n.added, n.expected))
+	eff.Flush()
+}
+
+// Expect sets the expected number of members. It is used as
+// total as long as fewer members have been added.
+func (n *barGroupNotifier) Expect(b ProgressElement, expected int) {
+	eff := b.(groupBar)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.expected = expected
+	eff.SetTotal(max(n.added, n.expected))
+	eff.Flush()
+}
+
+// Finish adjusts the bar to the actual number of
+// finished members.
+func (n *barGroupNotifier) Finish(b ProgressElement) {
+	eff := b.(groupBar)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.added > 0 {
+		eff.SetTotal(n.added)
+		eff.Set(n.added)
 	}
-	eff.elem.Protected().SetTotal(eff.elem.Protected().Total() + 1)
 }
 
 func (*barGroupNotifier) Done(b ProgressElement, p any) {
-	eff := b.(*IntBarBase[IntBarImpl])
+	eff := b.(groupBar)
 	eff.Incr()
 }
 
+// Aggregate shows the percent in steps of 0.1 percent.
+// 100% is only shown for the final state.
+func (n *barGroupNotifier) Aggregate(b ProgressElement, percent float64, final bool) {
+	eff := b.(groupBar)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	v := 1000
+	if !final {
+		v = min(int(percent*10), 999)
+	}
+	if n.started && v == n.current {
+		return
+	}
+	if !n.started {
+		eff.SetTotal(1000)
+		n.started = true
+	}
+	n.current = v
+	eff.Set(v)
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type IntBarInterface interface {
@@ -108,6 +173,7 @@ func (b *IntBarBaseImpl[T]) Set(n int) bool {
 		n = b.Protected().Total()
 	}
 	b.current = n
+	b.Touch()
 	b.Protected().Flush()
 	return true
 }
@@ -122,10 +188,15 @@ func (b *IntBarBaseImpl[T]) Incr() bool {
 
 	n := b.current + 1
 	b.current = n
+	b.Touch()
 	b.Protected().Flush()
 	return true
 }
 
+func (b *IntBarBaseImpl[T]) ResetProgress() {
+	b.current = 0
+}
+
 func (b *IntBarBaseImpl[T]) IsFinished() bool {
 	return b.current == b.Protected().Total()
 }
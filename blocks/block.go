@@ -8,16 +8,21 @@ import (
 	"os"
 	"runtime"
 	atomic2 "sync/atomic"
+	"time"
 
 	"github.com/mandelsoft/goutils/atomic"
 	"github.com/mandelsoft/goutils/general"
 	"github.com/mandelsoft/goutils/optionutils"
 	"github.com/mandelsoft/ttycolors"
-	"github.com/mandelsoft/ttycolors/ansi"
+	"github.com/mandelsoft/ttyprogress/units"
 )
 
 const DefaultView = 10
 
+// FocusMarker marks the focused Block if the
+// keyboard control is enabled.
+const FocusMarker = "▶ "
+
 var ErrNotAssigned = errors.New("uiblock not assigned")
 var ErrAlreadyAssigned = errors.New("uiblock already assigned")
 
@@ -45,25 +50,86 @@ type Block struct {
 	gap         string
 	followupGap string
 	contentGap  string
+	overflow    Overflow
+
+	prefix         string
+	followupPrefix string
+
+	linePrefixFunc LinePrefixFunc
+	lineFormatFunc LineFormatFunc
+	viewFilterFunc LineFilterFunc
+	durationFormat units.DurationFormat
 
 	startline bool
+	linestart int
+	cr        bool
+	escape    []byte
+	lines     []linemeta
+	started   time.Time
 
 	buf    bytes.Buffer
 	closed bool
+	failed bool
 	done   chan struct{}
 
 	final       []byte
 	hideOnClose bool
 	hidden      bool
+	folded      bool
+	expanded    bool
+
+	content  []byte
+	activity uint64
 
 	updated   atomic2.Bool
 	lastlines int
 
-	closer []func()
+	closer    []func()
+	discarder []func()
+	// discarded is set, when the block is finally written
+	// and removed from its Blocks object.
+	discarded bool
 }
 
 type block = Block
 
+// Line describes a line of a Block passed
+// to line decorations.
+type Line struct {
+	// Text is the line content without gaps.
+	Text string
+	// Time is the time the line has been started.
+	Time time.Time
+	// Elapsed is the time since the first line of the block
+	// has been started.
+	Elapsed time.Duration
+	// DurationFormat is the format configured for durations
+	// shown by line decorations, if any.
+	DurationFormat units.DurationFormat
+}
+
+// LinePrefixFunc provides a prefix for a line of a Block.
+// It must not contain a newline.
+type LinePrefixFunc func(l Line) string
+
+// LineFormatFunc provides a format for a line of a Block.
+// It may return nil to keep the line as it is.
+type LineFormatFunc func(l Line) ttycolors.FormatProvider
+
+// LineFilterFunc decides whether a line of a Block
+// should be shown in the view window.
+type LineFilterFunc func(l Line) bool
+
+// linemeta describes the start of the content
+// of a line in the buffer.
+type linemeta struct {
+	start int
+	time  time.Time
+}
+
+// activities is used to order the content changes of all blocks.
+var activities atomic2.Uint64
+
 // NewBlock provides a new Block not yet assigned to any Blocks
 // object. A Block can only be assigned once.
 func NewBlock(view ...int) *Block {
@@ -102,6 +168,15 @@ func (w *Block) RegisterCloser(f func()) {
 	w.closer = append(w.closer, f)
 }
 
+// RegisterDiscarder registers a function called when
+// the closed block is finally removed from its Blocks object.
+// It is called synchronously with the Blocks object locked
+// and must not call back into it.
+func (w *Block) RegisterDiscarder(f func()) {
+	defer w.lock()()
+	w.discarder = append(w.discarder, f)
+}
+
 func (w *Block) HideOnClose(b ...bool) *Block {
 	w.hideOnClose = optionutils.BoolOption(b...)
 	return w
@@ -122,6 +197,40 @@ func (w *Block) IsHidden() bool {
 	return w.hidden
 }
 
+// Fold folds (or unfolds) the block. Like hidden blocks,
+// folded blocks are not shown. Folding is used by containers
+// to collapse their content independently of the hidden state.
+func (w *Block) Fold(b ...bool) *Block {
+	defer w.lock()()
+
+	f := optionutils.BoolOption(b...)
+	if w.folded != f {
+		w.folded = f
+		w.Flush()
+	}
+	return w
+}
+
+// IsExpanded reports whether the complete content
+// is shown instead of the view window.
+func (w *Block) IsExpanded() bool {
+	defer w.rlock()()
+	return w.expanded
+}
+
+func (w *Block) IsFolded() bool {
+	defer w.rlock()()
+	return w.folded
+}
+
+// Activity provides a sequence number describing the
+// last content change of the block. A greater value
+// indicates a more recent change.
+func (w *Block) Activity() uint64 {
+	defer w.rlock()()
+	return w.activity
+}
+
 func (w *Block) SetTitleFormat(f ttycolors.Format) *Block {
 	defer w.lock()()
 
@@ -137,6 +246,43 @@ func (w *Block) SetViewFormat(f ttycolors.Format) *Block {
 	return w
 }
 
+// SetLinePrefixFunc sets a function providing a prefix
+// for every line of the block content.
+func (w *Block) SetLinePrefixFunc(f LinePrefixFunc) *Block {
+	defer w.lock()()
+
+	w.linePrefixFunc = f
+	return w
+}
+
+// SetDurationFormat sets the format provided to line
+// decorations for showing durations.
+func (w *Block) SetDurationFormat(f units.DurationFormat) *Block {
+	defer w.lock()()
+
+	w.durationFormat = f
+	return w
+}
+
+// SetLineFormatFunc sets a function providing a format
+// for every line of the block content.
+func (w *Block) SetLineFormatFunc(f LineFormatFunc) *Block {
+	defer w.lock()()
+
+	w.lineFormatFunc = f
+	return w
+}
+
+// SetViewFilterFunc sets a function selecting the lines
+// shown in the view window while the block is not closed.
+// After the block is closed, the complete content is shown.
+func (w *Block) SetViewFilterFunc(f LineFilterFunc) *Block {
+	defer w.lock()()
+
+	w.viewFilterFunc = f
+	return w
+}
+
 func (w *Block) SetTitleLine(s string) *Block {
 	defer w.lock()()
 
@@ -193,6 +339,38 @@ func (w *Block) SetContentGap(gap string) *Block {
 	return w
 }
 
+// SetLinePrefix sets prefixes prepended to the rendered
+// lines of the block. In contrast to gaps, line prefixes
+// are not part of the written content and may be changed
+// at any time, for example to render tree connectors.
+func (w *Block) SetLinePrefix(first, followup string) *Block {
+	defer w.lock()()
+
+	if w.prefix == first && w.followupPrefix == followup {
+		return w
+	}
+	w.prefix = first
+	w.followupPrefix = followup
+	w.Flush()
+	return w
+}
+
+// SetOverflow sets the handling of lines exceeding the
+// terminal width. By default, the policy of the Blocks
+// object is used.
+func (w *Block) SetOverflow(o Overflow) *Block {
+	defer w.lock()()
+
+	w.overflow = o
+	w.Flush()
+	return w
+}
+
+func (w *Block) GetOverflow() Overflow {
+	defer w.rlock()()
+	return w.overflow
+}
+
 func (w *Block) SetPayload(p any) *Block {
 	defer w.lock()()
 
@@ -230,42 +408,130 @@ func (w *Block) Reset() {
 	if w.closed {
 		return
 	}
+	w.reset()
+}
+
+func (w *Block) reset() {
 	w.startline = true
+	w.linestart = 0
+	w.cr = false
+	w.escape = nil
+	w.lines = nil
 	w.buf.Reset()
 }
 
 // Write save the contents of buf to the writer b. The only errors returned are ones encountered while writing to the underlying buffer.
+// A carriage return (\r) not followed by a newline discards the
+// current line as soon as new content is written, so that
+// in-place updates of a line keep only its last state.
+// The erase line sequence ESC[K is dropped.
 func (w *Block) Write(buf []byte) (n int, err error) {
 	defer w.lock()()
 	if w.closed {
 		return 0, os.ErrClosed
 	}
+	return w.write(buf)
+}
 
-	contentgap := w.followupGap + w.contentGap
-	gap := contentgap
-	if w.buf.Len() == 0 && w.titleline == "" {
-		gap = w.gap + w.contentGap
+// Rewrite replaces the content of the block. In contrast to Reset and
+// Write, it can also be used for a closed block, as long as it is
+// still shown, for example, to adapt the column alignment of
+// a finished progress element.
+func (w *Block) Rewrite(buf []byte) (n int, err error) {
+	defer w.lock()()
+	if w.discarded {
+		return 0, os.ErrClosed
 	}
-	if gap != "" {
-		for _, b := range buf {
-			if b == '\n' {
-				w.startline = true
-				gap = contentgap
-			} else {
-				if w.startline {
-					w.buf.Write([]byte(gap))
-				}
-				w.startline = false
+	w.reset()
+	return w.write(buf)
+}
+
+func (w *Block) write(buf []byte) (n int, err error) {
+	for _, b := range buf {
+		if w.escape != nil {
+			w.writeEscape(b)
+			continue
+		}
+		if w.cr {
+			w.cr = false
+			if b != '\n' {
+				w.discardLine()
 			}
-			w.buf.WriteByte(b)
 		}
-	} else {
-		n, err = w.buf.Write(buf)
+		switch b {
+		case '\r':
+			w.cr = true
+		case ESC:
+			w.escape = []byte{b}
+		default:
+			w.writeByte(b)
+		}
 	}
 	if w.auto {
 		w.Flush()
 	}
-	return n, err
+	return len(buf), nil
+}
+
+// writeByte adds a content byte to the buffer
+// prepending the gap at the beginning of a line.
+func (w *Block) writeByte(b byte) {
+	if b == '\n' {
+		w.buf.WriteByte(b)
+		w.startline = true
+		w.linestart = w.buf.Len()
+		return
+	}
+	if w.startline {
+		if w.linestart == 0 && w.titleline == "" {
+			w.buf.WriteString(w.gap + w.contentGap)
+		} else {
+			w.buf.WriteString(w.followupGap + w.contentGap)
+		}
+		w.startline = false
+		w.addLine()
+	}
+	w.buf.WriteByte(b)
+}
+
+// writeEscape collects the bytes of an escape sequence.
+// Complete sequences are added to the buffer, except
+// the erase line sequence, which is dropped.
+func (w *Block) writeEscape(b byte) {
+	w.escape = append(w.escape, b)
+	if len(w.escape) == 2 {
+		if b == '[' {
+			return
+		}
+	} else if b < 0x40 || b > 0x7e {
+		return
+	}
+	seq := w.escape
+	w.escape = nil
+	if s := string(seq); s == "\x1b[K" || s == "\x1b[0K" {
+		return
+	}
+	for _, c := range seq {
+		w.writeByte(c)
+	}
+}
+
+// addLine records the start of the content of a new line.
+func (w *Block) addLine() {
+	now := time.Now()
+	if w.started.IsZero() {
+		w.started = now
+	}
+	w.lines = append(w.lines, linemeta{w.buf.Len(), now})
+}
+
+// discardLine removes the current line from the buffer.
+func (w *Block) discardLine() {
+	if !w.startline {
+		w.lines = w.lines[:len(w.lines)-1]
+	}
+	w.buf.Truncate(w.linestart)
+	w.startline = true
 }
 
 func (w *Block) Flush() error {
@@ -303,6 +569,20 @@ func (w *Block) Close() error {
 	return nil
 }
 
+// Fail marks the block as representing a failed element.
+func (w *Block) Fail() *Block {
+	defer w.lock()()
+
+	w.failed = true
+	w.Flush()
+	return w
+}
+
+func (w *Block) IsFailed() bool {
+	defer w.rlock()()
+	return w.failed
+}
+
 func (w *Block) IsClosed() bool {
 	defer w.rlock()()
 	return w.closed
@@ -332,6 +612,52 @@ func (w *Block) _formatTitle(v string) string {
 	return w.Blocks().GetTTYGontext().StringWith(w.titleFormat, v).String()
 }
 
+// _content provides the content with the line prefixes
+// and line formats applied. If filtered is set, only
+// the lines accepted by the view filter are provided.
+func (w *Block) _content(filtered bool) []byte {
+	data := w.buf.Bytes()
+	filter := filtered && w.viewFilterFunc != nil
+	if !filter && w.linePrefixFunc == nil && w.lineFormatFunc == nil {
+		return data
+	}
+
+	var buf bytes.Buffer
+	m := 0
+	for start := 0; start < len(data); {
+		end := bytes.IndexByte(data[start:], '\n')
+		next := len(data)
+		if end < 0 {
+			end = len(data)
+		} else {
+			end += start
+			next = end + 1
+		}
+		if m < len(w.lines) && w.lines[m].start <= end {
+			meta := w.lines[m]
+			m++
+			l := Line{Text: string(data[meta.start:end]), Time: meta.time, Elapsed: meta.time.Sub(w.started), DurationFormat: w.durationFormat}
+			if !filter || w.viewFilterFunc(l) {
+				buf.Write(data[start:meta.start])
+				if w.linePrefixFunc != nil {
+					buf.WriteString(w.linePrefixFunc(l))
+				}
+				if w.lineFormatFunc != nil {
+					if f := w.lineFormatFunc(l); f != nil {
+						l.Text = w.Blocks().GetTTYGontext().StringWith(f, l.Text).String()
+					}
+				}
+				buf.WriteString(l.Text)
+				buf.Write(data[end:next])
+			}
+		} else if !filter {
+			buf.Write(data[start:next])
+		}
+		start = next
+	}
+	return buf.Bytes()
+}
+
 func (w *Block) _formatView(v []byte) []byte {
 	if w.viewFormat == nil {
 		return v
@@ -339,22 +665,53 @@ func (w *Block) _formatView(v []byte) []byte {
 	return []byte(w.Blocks().GetTTYGontext().StringWith(w.viewFormat, v).String())
 }
 
+// _truncate reports whether lines exceeding the
+// terminal width should be truncated.
+func (w *Block) _truncate(blocks *Blocks) bool {
+	if !blocks.overFlowHandled {
+		return false
+	}
+	if w.overflow == OverflowDefault {
+		return blocks.overflow == OverflowTruncate
+	}
+	return w.overflow == OverflowTruncate
+}
+
 func (w *Block) emit(final bool) (int, error) {
 	blocks := w.blocks.Load()
 
-	if w.hidden {
+	if !bytes.Equal(w.content, w.buf.Bytes()) {
+		w.content = bytes.Clone(w.buf.Bytes())
+		w.activity = activities.Add(1)
+	}
+	if w.hidden || w.folded || (!final && w.closed && blocks.hideFinished) {
 		w.lastlines = 0
 		return 0, nil
 	}
+	truncate := w._truncate(blocks)
 	lines := 0
 	titleline := 0
 	newline := false
-	data := w.buf.Bytes()
+	prefix, followupPrefix := w.prefix, w.followupPrefix
+	if blocks.keyboard && !final {
+		// reserve a column for the focus marker
+		if blocks.focus == w {
+			prefix = FocusMarker + prefix
+		} else {
+			prefix = "  " + prefix
+		}
+		followupPrefix = "  " + followupPrefix
+	}
+	data := w._content(!final && !w.expanded)
 	if w.closed && w.final != nil {
 		data = []byte(w._formatTitle(string(w.final)))
 	} else {
 		if w.titleline != "" {
-			blocks.out.Write([]byte(w.gap + w._formatTitle(w.titleline) + "\n"))
+			title := prefix + w.gap + w._formatTitle(w.titleline)
+			if truncate {
+				title = Truncate(title, blocks.termWidth)
+			}
+			blocks.out.Write([]byte(title + "\n"))
 			titleline = 1
 		}
 	}
@@ -362,39 +719,42 @@ func (w *Block) emit(final bool) (int, error) {
 		w.lastlines = titleline
 		return titleline, nil
 	}
+	if prefix != "" || followupPrefix != "" {
+		if titleline > 0 {
+			data = prefixLines(data, followupPrefix, followupPrefix)
+		} else {
+			data = prefixLines(data, prefix, followupPrefix)
+		}
+	}
+	if truncate {
+		data = truncateLines(data, blocks.termWidth)
+	}
 
 	implicit := 0
 	linestart := make([]lineinfo, w.view)
 
-	escapeSequence := 0
-
 	var col int
 	start := 0
+	o := 0
 	// fmt.Fprintf(os.Stderr, "write [%d] %q\n", len(data), string(data))
-	for o, b := range string(data) {
-		if escapeSequence == 0 {
-			escapeSequence = ansi.EscapeLength(data[o:])
-		}
-		if escapeSequence == 0 && b == '\n' || (blocks.overFlowHandled && col >= blocks.termWidth) {
-			if b != '\n' {
-				implicit++
-			} else {
-				linestart[lines%w.view].start = start
-				linestart[lines%w.view].implicit = implicit
-				start = o + 1
-				lines++
-			}
+	for _, seg := range segments(string(data)) {
+		if !seg.escape && seg.text == "\n" {
+			linestart[lines%w.view].start = start
+			linestart[lines%w.view].implicit = implicit
+			start = o + 1
+			lines++
 			newline = true
 			col = 0
-		} else {
-			// fmt.Fprintf(os.Stderr, "insert linebreak %d\n", col)
-			newline = false
-			if escapeSequence > 0 {
-				escapeSequence--
-			} else {
-				col++
+		} else if seg.width > 0 {
+			if blocks.overFlowHandled && col+seg.width > blocks.termWidth {
+				// fmt.Fprintf(os.Stderr, "insert linebreak %d\n", col)
+				implicit++
+				col = 0
 			}
+			newline = false
+			col += seg.width
 		}
+		o += len(seg.text)
 	}
 
 	if !newline {
@@ -411,7 +771,7 @@ func (w *Block) emit(final bool) (int, error) {
 	var err error
 	var eff int
 
-	if final || lines <= w.view {
+	if final || w.expanded || lines <= w.view {
 		_, err = blocks.out.Write(w._formatView(data))
 		eff = lines + implicit + titleline
 		// fmt.Fprintf(os.Stderr, "data: %s\n", string(data))
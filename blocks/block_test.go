@@ -2,6 +2,7 @@ package blocks_test
 
 import (
 	"bytes"
+	"strings"
 	"time"
 
 	. "github.com/mandelsoft/goutils/testutils"
@@ -33,3 +34,120 @@ var _ = Describe("Blocks Test Environment", func() {
 		Expect(s).To(Equal("test\n"))
 	})
 })
+
+var _ = Describe("Truncation", func() {
+	It("truncates at the end", func() {
+		Expect(blocks.Truncate("0123456789", 5)).To(Equal("0123…"))
+		Expect(blocks.Truncate("01234", 5)).To(Equal("01234"))
+	})
+
+	It("truncates in the middle", func() {
+		Expect(blocks.TruncateMiddle("0123456789", 5)).To(Equal("01…89"))
+		Expect(blocks.TruncateMiddle("0123456789", 4)).To(Equal("01…9"))
+	})
+
+	It("keeps escape sequences", func() {
+		Expect(blocks.Truncate("\x1b[1m01234\x1b[0m56789", 3)).To(Equal("\x1b[1m01…\x1b[0m"))
+	})
+})
+
+var _ = Describe("Display width", func() {
+	It("measures wide and combining characters", func() {
+		Expect(blocks.StringWidth("abc")).To(Equal(3))
+		Expect(blocks.StringWidth("日本語")).To(Equal(6))
+		Expect(blocks.StringWidth("é")).To(Equal(1))
+		Expect(blocks.StringWidth("\x1b[1m日本\x1b[0m")).To(Equal(4))
+		Expect(blocks.StringWidth("🇩🇪")).To(Equal(2))
+		Expect(blocks.StringWidth("👩‍💻")).To(Equal(2))
+	})
+
+	It("truncates wide characters", func() {
+		Expect(blocks.Truncate("日本語テキスト", 6)).To(Equal("日本…"))
+		Expect(blocks.Truncate("日本語x", 4)).To(Equal("日…"))
+		Expect(blocks.TruncateMiddle("日本語テキスト", 7)).To(Equal("日…スト"))
+	})
+
+	It("aligns by display width", func() {
+		Expect(blocks.AlignLeft([]string{"日本", "a"})).To(Equal([]string{"日本", "a   "}))
+	})
+})
+
+var _ = Describe("Carriage return", func() {
+	var blks *blocks.Blocks
+	var buf *bytes.Buffer
+
+	BeforeEach(func() {
+		buf = bytes.NewBuffer(nil)
+		blks = blocks.New(buf)
+	})
+
+	It("overwrites the current line", func() {
+		b := blocks.NewBlock(3)
+		MustBeSuccessful(blks.AddBlock(b))
+
+		Expect(b.Write([]byte("first\n 10%"))).To(Equal(10))
+		Expect(b.Write([]byte("\r 50%\r"))).To(Equal(6))
+		Expect(b.Write([]byte("\x1b[K100%\r\n"))).To(Equal(9))
+		Expect(b.Write([]byte("last"))).To(Equal(4))
+		MustBeSuccessful(blks.Close())
+		MustBeSuccessful(b.Close())
+		MustBeSuccessful(blks.Wait(nil))
+
+		Expect(buf.String()).To(HaveSuffix("first\n100%\nlast\n"))
+	})
+
+	It("keeps the gap", func() {
+		b := blocks.NewBlock(3).SetGap("* ")
+		MustBeSuccessful(blks.AddBlock(b))
+
+		Expect(b.Write([]byte("a\rb"))).To(Equal(3))
+		MustBeSuccessful(blks.Close())
+		MustBeSuccessful(b.Close())
+		MustBeSuccessful(blks.Wait(nil))
+
+		Expect(buf.String()).To(HaveSuffix("* b\n"))
+	})
+})
+
+var _ = Describe("Line decoration", func() {
+	It("prefixes lines", func() {
+		buf := bytes.NewBuffer(nil)
+		blks := blocks.New(buf)
+		b := blocks.NewBlock(3).SetGap("* ").SetLinePrefixFunc(func(l blocks.Line) string {
+			if strings.HasPrefix(l.Text, "E") {
+				return "[err] "
+			}
+			return "[   ] "
+		})
+		MustBeSuccessful(blks.AddBlock(b))
+
+		Expect(b.Write([]byte("info\nError\r"))).To(Equal(11))
+		Expect(b.Write([]byte("Error\n"))).To(Equal(6))
+		MustBeSuccessful(blks.Close())
+		MustBeSuccessful(b.Close())
+		MustBeSuccessful(blks.Wait(nil))
+
+		Expect(buf.String()).To(HaveSuffix("* [   ] info\n* [err] Error\n"))
+	})
+})
+
+var _ = Describe("View filter", func() {
+	It("filters the view window", func() {
+		buf := bytes.NewBuffer(nil)
+		blks := blocks.New(buf)
+		b := blocks.NewBlock(2).SetViewFilterFunc(func(l blocks.Line) bool {
+			return strings.HasPrefix(l.Text, "Compiling")
+		})
+		MustBeSuccessful(blks.AddBlock(b))
+
+		Expect(b.Write([]byte("Compiling a\nnoise\nCompiling b\nnoise\n"))).To(Equal(36))
+		MustBeSuccessful(b.Flush())
+		time.Sleep(blocks.MIN_UPDATE_INTERVAL * 2)
+		Expect(buf.String()).To(Equal("Compiling a\nCompiling b\n"))
+
+		MustBeSuccessful(blks.Close())
+		MustBeSuccessful(b.Close())
+		MustBeSuccessful(blks.Wait(nil))
+		Expect(buf.String()).To(HaveSuffix("Compiling a\nnoise\nCompiling b\nnoise\n"))
+	})
+})
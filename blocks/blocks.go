@@ -31,10 +31,18 @@ type Blocks struct {
 	termWidth int
 
 	overFlowHandled bool
+	overflow        Overflow
 
 	blocks    []*Block
 	lineCount int
 
+	// interactive control
+	keyboard     bool
+	restore      func()
+	focus        *Block
+	paused       bool
+	hideFinished bool
+
 	closeOnDone bool
 	closed      bool
 	done        chan struct{}
@@ -90,6 +98,9 @@ func (w *Blocks) Done() <-chan struct{} {
 func (w *Blocks) _flush() {
 	w.lock.Lock()
 	defer w.lock.Unlock()
+	if w.paused {
+		return
+	}
 	// clearLines(w.out, w.lineCount)
 	// w.flushAll()
 	w.deltaFlush()
@@ -191,6 +202,23 @@ func (w *Blocks) Blocks() []*Block {
 	return slices.Clone(w.blocks)
 }
 
+// SetOverflow sets the default handling of lines exceeding
+// the terminal width for all Block/s not configuring
+// an own policy. The default is OverflowWrap.
+func (w *Blocks) SetOverflow(o Overflow) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.overflow = o
+}
+
+func (w *Blocks) GetOverflow() Overflow {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	return w.overflow
+}
+
 func (w *Blocks) TermWidth() int {
 	w.lock.RLock()
 	defer w.lock.RUnlock()
@@ -246,6 +274,9 @@ func (w *Blocks) Wait(ctx context.Context) error {
 }
 
 func (w *Blocks) discardBlock() error {
+	if w.paused {
+		return nil
+	}
 	discarded := false
 	for len(w.blocks) > 0 && w.blocks[0].closed {
 		if !discarded {
@@ -253,6 +284,10 @@ func (w *Blocks) discardBlock() error {
 			discarded = true
 		}
 		w.blocks[0].emit(true)
+		w.blocks[0].discarded = true
+		for _, d := range w.blocks[0].discarder {
+			d()
+		}
 		w.blocks = w.blocks[1:]
 	}
 	if discarded {
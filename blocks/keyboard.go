@@ -0,0 +1,257 @@
+package blocks
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"slices"
+
+	"golang.org/x/term"
+
+	"github.com/mandelsoft/goutils/general"
+)
+
+// Keys used for the interactive control of a Blocks object.
+const (
+	keyInterrupt = 3
+	keyTab       = '\t'
+	keyEnter     = '\r'
+)
+
+// EnableKeyboard enables the interactive control of the Blocks
+// object by the keyboard. The given terminal is put into raw mode
+// until the Blocks object is done. The following keys are handled:
+//
+//   - Tab, n, j, arrow down: focus the next Block
+//   - Shift-Tab, N, k, arrow up: focus the previous Block
+//   - Enter, e: expand or collapse the view of the focused Block
+//   - f: toggle showing finished Block/s
+//   - Space, p: pause or resume rendering
+//   - Ctrl-C: restore the terminal and interrupt the process
+//
+// The focused Block is marked by a leading marker.
+//
+// If closeOnDone is set to true, the Blocks object takes the ownership
+// of the given file and closes it after the terminal has been restored.
+func (w *Blocks) EnableKeyboard(in *os.File, closeOnDone ...bool) error {
+	keys, err := newKeyReader(in)
+	if err != nil {
+		return err
+	}
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		keys.Close()
+		return err
+	}
+
+	w.lock.Lock()
+	if w.restore != nil {
+		w.lock.Unlock()
+		term.Restore(int(in.Fd()), state)
+		keys.Close()
+		return ErrAlreadyAssigned
+	}
+	restored := false
+	w.restore = func() {
+		if !restored {
+			restored = true
+			term.Restore(int(in.Fd()), state)
+		}
+	}
+	w.keyboard = true
+	if f, ok := w.out.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		// raw mode disables the output processing of the terminal.
+		w.out = &crlfWriter{f}
+	}
+	w.lock.Unlock()
+
+	owned := general.Optional(closeOnDone...)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		w.readKeys(keys)
+	}()
+	go func() {
+		<-w.done
+		w.lock.Lock()
+		w.restore()
+		w.lock.Unlock()
+
+		// the key reader must not consume input
+		// after the Blocks object is done.
+		keys.Cancel()
+		<-stopped
+		keys.Close()
+		if owned {
+			in.Close()
+		}
+	}()
+	return nil
+}
+
+func (w *Blocks) readKeys(in io.Reader) {
+	buf := make([]byte, 16)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return
+		}
+		select {
+		case <-w.done:
+			return
+		default:
+		}
+		w.handleKeys(buf[:n])
+	}
+}
+
+func (w *Blocks) handleKeys(keys []byte) {
+	for i := 0; i < len(keys); i++ {
+		switch keys[i] {
+		case ESC:
+			if i+2 < len(keys) && keys[i+1] == '[' {
+				switch keys[i+2] {
+				case 'A', 'Z':
+					w.FocusPrevious()
+				case 'B':
+					w.FocusNext()
+				}
+				i += 2
+			}
+		case keyTab, 'n', 'j':
+			w.FocusNext()
+		case 'N', 'k':
+			w.FocusPrevious()
+		case keyEnter, 'e':
+			w.ExpandFocused()
+		case 'f':
+			w.ShowFinished(!w.IsShowFinished())
+		case ' ', 'p':
+			w.Pause(!w.IsPaused())
+		case keyInterrupt:
+			w.lock.Lock()
+			w.restore()
+			w.lock.Unlock()
+			if p, err := os.FindProcess(os.Getpid()); err == nil {
+				p.Signal(os.Interrupt)
+			}
+		}
+	}
+}
+
+// Focused provides the focused Block, if any.
+func (w *Blocks) Focused() *Block {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.focus
+}
+
+// FocusNext focuses the next visible Block.
+func (w *Blocks) FocusNext() {
+	w.moveFocus(1)
+}
+
+// FocusPrevious focuses the previous visible Block.
+func (w *Blocks) FocusPrevious() {
+	w.moveFocus(-1)
+}
+
+func (w *Blocks) moveFocus(d int) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var candidates []*Block
+	for _, b := range w.blocks {
+		if !b.hidden && !b.folded && !(b.closed && w.hideFinished) {
+			candidates = append(candidates, b)
+		}
+	}
+	if len(candidates) == 0 {
+		w.focus = nil
+		return
+	}
+	i := slices.Index(candidates, w.focus)
+	switch {
+	case i >= 0:
+		i = (i + d + len(candidates)) % len(candidates)
+	case d > 0:
+		i = 0
+	default:
+		i = len(candidates) - 1
+	}
+	w.focus = candidates[i]
+	w.redraw()
+}
+
+// ExpandFocused expands or collapses the view
+// of the focused Block.
+func (w *Blocks) ExpandFocused() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.focus != nil {
+		w.focus.expanded = !w.focus.expanded
+		w.redraw()
+	}
+}
+
+// ShowFinished sets whether closed Block/s still waiting
+// for their predecessors are shown. By default, they are shown.
+func (w *Blocks) ShowFinished(b ...bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.hideFinished = !general.OptionalDefaultedBool(true, b...)
+	w.redraw()
+}
+
+func (w *Blocks) IsShowFinished() bool {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return !w.hideFinished
+}
+
+// Pause pauses or resumes the rendering. While paused,
+// the Blocks object does not get done.
+func (w *Blocks) Pause(b ...bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	paused := general.OptionalDefaultedBool(true, b...)
+	if w.paused == paused {
+		return
+	}
+	w.paused = paused
+	if !paused {
+		w.discardBlock()
+		w.redraw()
+	}
+}
+
+func (w *Blocks) IsPaused() bool {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.paused
+}
+
+// redraw requests a complete redraw of all Block/s.
+func (w *Blocks) redraw() {
+	if len(w.blocks) > 0 {
+		w.blocks[0].updated.Store(true)
+	}
+	w.requestFlush()
+}
+
+// crlfWriter maps newlines to carriage return and newline
+// for terminals in raw mode.
+type crlfWriter struct {
+	out io.Writer
+}
+
+func (w *crlfWriter) Write(data []byte) (int, error) {
+	_, err := w.out.Write(bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n")))
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
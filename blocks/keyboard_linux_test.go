@@ -0,0 +1,118 @@
+package blocks_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/term"
+
+	"github.com/mandelsoft/ttyprogress/blocks"
+)
+
+func openPTY() (*os.File, *os.File, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	var n, unlock uint32
+	if _, _, e := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); e != 0 {
+		master.Close()
+		return nil, nil, e
+	}
+	if _, _, e := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); e != 0 {
+		master.Close()
+		return nil, nil, e
+	}
+	slave, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+	return master, slave, nil
+}
+
+var _ = Describe("Keyboard", func() {
+	var master, slave *os.File
+
+	BeforeEach(func() {
+		var err error
+		master, slave, err = openPTY()
+		if err != nil {
+			Skip("no pty available: " + err.Error())
+		}
+	})
+
+	AfterEach(func() {
+		master.Close()
+		slave.Close()
+	})
+
+	It("handles keys", func() {
+		buf := bytes.NewBuffer(nil)
+		blks := blocks.New(buf)
+		b1 := blocks.NewBlock(1)
+		b2 := blocks.NewBlock(1)
+		MustBeSuccessful(blks.AddBlock(b1))
+		MustBeSuccessful(blks.AddBlock(b2))
+		Expect(b2.Write([]byte("line 1\nline 2\n"))).To(Equal(14))
+
+		state := Must(term.GetState(int(slave.Fd())))
+		MustBeSuccessful(blks.EnableKeyboard(slave))
+		Expect(term.GetState(int(slave.Fd()))).NotTo(Equal(state))
+
+		master.Write([]byte("n"))
+		Eventually(blks.Focused).Should(BeIdenticalTo(b1))
+		master.Write([]byte("\x1b[B"))
+		Eventually(blks.Focused).Should(BeIdenticalTo(b2))
+		master.Write([]byte("e"))
+		Eventually(b2.IsExpanded).Should(BeTrue())
+		master.Write([]byte("f"))
+		Eventually(blks.IsShowFinished).Should(BeFalse())
+		master.Write([]byte("p"))
+		Eventually(blks.IsPaused).Should(BeTrue())
+		master.Write([]byte("p"))
+		Eventually(blks.IsPaused).Should(BeFalse())
+
+		MustBeSuccessful(blks.Close())
+		MustBeSuccessful(b1.Close())
+		MustBeSuccessful(b2.Close())
+		MustBeSuccessful(blks.Wait(nil))
+		Expect(buf.String()).To(ContainSubstring(blocks.FocusMarker + "line 1\n  line 2\n"))
+		Expect(buf.String()).To(HaveSuffix("line 1\nline 2\n"))
+		Eventually(func() *term.State { return Must(term.GetState(int(slave.Fd()))) }).Should(Equal(state))
+	})
+
+	It("closes an owned terminal", func() {
+		blks := blocks.New(bytes.NewBuffer(nil))
+		MustBeSuccessful(blks.EnableKeyboard(slave, true))
+		_, err := slave.Stat()
+		Expect(err).To(Succeed())
+
+		MustBeSuccessful(blks.Close())
+		MustBeSuccessful(blks.Wait(nil))
+		Eventually(func() error { _, err := slave.Stat(); return err }).Should(MatchError(os.ErrClosed))
+	})
+
+	It("leaves a given terminal usable", func() {
+		blks := blocks.New(bytes.NewBuffer(nil))
+		MustBeSuccessful(blks.EnableKeyboard(slave))
+
+		MustBeSuccessful(blks.Close())
+		MustBeSuccessful(blks.Wait(nil))
+
+		// wait for the key reader to be cancelled before providing input.
+		time.Sleep(100 * time.Millisecond)
+		master.Write([]byte("x\n"))
+		buf := make([]byte, 16)
+		n, err := slave.Read(buf)
+		Expect(err).To(Succeed())
+		Expect(string(buf[:n])).To(Equal("x\n"))
+	})
+})
@@ -0,0 +1,64 @@
+//go:build !windows
+
+package blocks
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// keyReader reads the keys from a terminal. A pending read can be
+// cancelled, also for files not supporting read deadlines, by polling
+// the terminal together with a pipe closed on cancellation.
+type keyReader struct {
+	in     *os.File
+	cancel *os.File
+	wakeup *os.File
+	once   sync.Once
+}
+
+func newKeyReader(in *os.File) (*keyReader, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	return &keyReader{in: in, cancel: w, wakeup: r}, nil
+}
+
+func (k *keyReader) Read(buf []byte) (int, error) {
+	fds := []unix.PollFd{
+		{Fd: int32(k.in.Fd()), Events: unix.POLLIN},
+		{Fd: int32(k.wakeup.Fd()), Events: unix.POLLIN},
+	}
+	for {
+		_, err := unix.Poll(fds, -1)
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if fds[1].Revents != 0 {
+			return 0, io.EOF
+		}
+		if fds[0].Revents != 0 {
+			return k.in.Read(buf)
+		}
+	}
+}
+
+// Cancel cancels a pending and all further reads.
+func (k *keyReader) Cancel() {
+	k.once.Do(func() { k.cancel.Close() })
+}
+
+// Close releases the resources of the reader.
+// The terminal is not closed.
+func (k *keyReader) Close() error {
+	k.Cancel()
+	return k.wakeup.Close()
+}
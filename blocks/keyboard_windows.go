@@ -0,0 +1,33 @@
+//go:build windows
+
+package blocks
+
+import (
+	"os"
+	"time"
+)
+
+// keyReader reads the keys from a terminal.
+// A pending read is cancelled by a read deadline.
+type keyReader struct {
+	in *os.File
+}
+
+func newKeyReader(in *os.File) (*keyReader, error) {
+	return &keyReader{in: in}, nil
+}
+
+func (k *keyReader) Read(buf []byte) (int, error) {
+	return k.in.Read(buf)
+}
+
+// Cancel cancels a pending and all further reads.
+func (k *keyReader) Cancel() {
+	k.in.SetReadDeadline(time.Now())
+}
+
+// Close releases the resources of the reader.
+// The read deadline of the terminal is reset.
+func (k *keyReader) Close() error {
+	return k.in.SetReadDeadline(time.Time{})
+}
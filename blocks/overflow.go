@@ -0,0 +1,93 @@
+package blocks
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Ellipsis is used to indicate truncated content.
+const Ellipsis = "…"
+
+// Overflow describes the handling of lines exceeding
+// the terminal width.
+type Overflow int
+
+const (
+	// OverflowDefault uses the policy of the Blocks object.
+	OverflowDefault Overflow = iota
+	// OverflowWrap wraps long lines (default).
+	OverflowWrap
+	// OverflowTruncate truncates long lines using an Ellipsis.
+	OverflowTruncate
+)
+
+// Truncate truncates a string to the given number of terminal
+// columns by replacing the trailing part with an Ellipsis.
+// Escape sequences are preserved to keep formats balanced.
+// A non-positive width leaves the string unchanged.
+func Truncate(s string, width int) string {
+	if width <= 0 || StringWidth(s) <= width {
+		return s
+	}
+	return elide(s, width-1, 0)
+}
+
+// TruncateMiddle truncates a string to the given number of terminal
+// columns by replacing the middle part with an Ellipsis.
+// Escape sequences are preserved to keep formats balanced.
+// A non-positive width leaves the string unchanged.
+func TruncateMiddle(s string, width int) string {
+	if width <= 0 || StringWidth(s) <= width {
+		return s
+	}
+	return elide(s, width/2, width)
+}
+
+// elide keeps the leading grapheme clusters fitting into head columns
+// and replaces the rest by an Ellipsis. If a width is given, the
+// trailing clusters fitting into the remaining columns are kept, also.
+// Escape sequences of the elided part are kept.
+func elide(s string, head, width int) string {
+	var buf strings.Builder
+
+	segs := segments(s)
+
+	// determine end of leading part
+	first := 0
+	w := 0
+	for ; first < len(segs); first++ {
+		seg := segs[first]
+		if w+seg.width > head {
+			break
+		}
+		w += seg.width
+	}
+
+	// determine start of trailing part
+	last := len(segs)
+	for tail := width - 1 - w; width > 0 && last > first; last-- {
+		seg := segs[last-1]
+		if seg.width > tail {
+			break
+		}
+		tail -= seg.width
+	}
+
+	for i, seg := range segs {
+		if i == first {
+			buf.WriteString(Ellipsis)
+		}
+		if seg.escape || i < first || i >= last {
+			buf.WriteString(seg.text)
+		}
+	}
+	return buf.String()
+}
+
+func truncateLines(data []byte, width int) []byte {
+	lines := bytes.Split(data, []byte{'\n'})
+	for i, l := range lines {
+		lines[i] = []byte(Truncate(string(l), width))
+	}
+	return bytes.Join(lines, []byte{'\n'})
+}
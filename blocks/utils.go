@@ -1,5 +1,30 @@
 package blocks
 
+import (
+	"bytes"
+)
+
 func GetTerminalSize() (int, int) {
 	return getTermSize()
 }
+
+// prefixLines prepends the given prefixes to the lines of data.
+// A trailing newline does not start a new line.
+func prefixLines(data []byte, first, followup string) []byte {
+	var buf bytes.Buffer
+
+	prefix := first
+	start := true
+	for _, b := range data {
+		if start {
+			buf.WriteString(prefix)
+			prefix = followup
+			start = false
+		}
+		buf.WriteByte(b)
+		if b == '\n' {
+			start = true
+		}
+	}
+	return buf.Bytes()
+}
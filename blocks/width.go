@@ -0,0 +1,160 @@
+package blocks
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mandelsoft/ttycolors/ansi"
+	"golang.org/x/text/width"
+)
+
+const (
+	zeroWidthJoiner   = '\u200d'
+	variationSelector = '\ufe0f'
+)
+
+// RuneWidth provides the number of terminal columns
+// used to display a single rune.
+// East-Asian wide and fullwidth runes use two columns,
+// control characters and combining marks none.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case r >= 0x1160 && r <= 0x11ff:
+		// Hangul Jamo vowels and final consonants combine
+		// with the preceding syllable.
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// segment is a piece of text, which is either an
+// escape sequence or a grapheme cluster.
+type segment struct {
+	text   string
+	width  int
+	escape bool
+}
+
+// segments splits a string into escape sequences and
+// (approximated) grapheme clusters with their display width.
+// A cluster consists of a base rune followed by combining
+// marks, variation selectors, and runes joined by a
+// zero-width joiner. Pairs of regional indicators
+// (flags) form a single cluster.
+func segments(s string) []segment {
+	var result []segment
+
+	data := []byte(s)
+	for i := 0; i < len(data); {
+		if l := ansi.EscapeLength(data[i:]); l > 0 {
+			result = append(result, segment{text: s[i : i+l], escape: true})
+			i += l
+			continue
+		}
+		r, l := utf8.DecodeRune(data[i:])
+		start := i
+		w := RuneWidth(r)
+		regional := isRegionalIndicator(r)
+		i += l
+	loop:
+		for i < len(data) {
+			n, nl := utf8.DecodeRune(data[i:])
+			switch {
+			case n == zeroWidthJoiner:
+				i += nl
+				if i < len(data) && ansi.EscapeLength(data[i:]) == 0 {
+					j, jl := utf8.DecodeRune(data[i:])
+					w = max(w, RuneWidth(j))
+					i += jl
+				}
+			case n == variationSelector:
+				w = max(w, 2)
+				i += nl
+			case regional && isRegionalIndicator(n):
+				regional = false
+				w = 2
+				i += nl
+			case !unicode.IsControl(n) && RuneWidth(n) == 0:
+				i += nl
+			default:
+				break loop
+			}
+		}
+		result = append(result, segment{text: s[start:i], width: w})
+	}
+	return result
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// StringWidth provides the number of terminal columns
+// used to display a string. Escape sequences are ignored.
+func StringWidth(s string) int {
+	n := 0
+	for _, seg := range segments(s) {
+		n += seg.width
+	}
+	return n
+}
+
+// SplitAt splits a string after the given number of terminal columns.
+// Escape sequences directly following the split column are kept
+// with the second part.
+func SplitAt(s string, col int) (string, string) {
+	n := 0
+	offset := 0
+	for _, seg := range segments(s) {
+		if !seg.escape && n+seg.width > col {
+			return s[:offset], s[offset:]
+		}
+		if seg.escape && n >= col {
+			return s[:offset], s[offset:]
+		}
+		n += seg.width
+		offset += len(seg.text)
+	}
+	return s, ""
+}
+
+// PadRight pads a string with blanks to the given
+// number of terminal columns.
+func PadRight(s string, w int) string {
+	if n := w - StringWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// PadLeft prepends blanks to a string to fill the
+// given number of terminal columns.
+func PadLeft(s string, w int) string {
+	if n := w - StringWidth(s); n > 0 {
+		return strings.Repeat(" ", n) + s
+	}
+	return s
+}
+
+// AlignLeft pads all strings of a list to the display
+// width of the longest one.
+func AlignLeft(list []string) []string {
+	w := 0
+	for _, s := range list {
+		w = max(w, StringWidth(s))
+	}
+	result := make([]string, len(list))
+	for i, s := range list {
+		result[i] = PadRight(s, w)
+	}
+	return result
+}
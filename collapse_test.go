@@ -0,0 +1,88 @@
+package ttyprogress_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+// members provides the shown member lines.
+func members(lines []string) []string {
+	var r []string
+	for _, l := range lines {
+		if i := strings.Index(l, "member"); i >= 0 {
+			r = append(r, l[i:i+7])
+		} else if i := strings.Index(l, "✓"); i >= 0 {
+			r = append(r, l[i:])
+		}
+	}
+	return r
+}
+
+var _ = Describe("Collapse Test Environment", func() {
+	var buf *syncBuffer
+	var p ttyprogress.Context
+	var bars []ttyprogress.Bar
+
+	BeforeEach(func() {
+		buf = &syncBuffer{}
+		p = ttyprogress.For(buf)
+	})
+
+	AfterEach(func() {
+		for _, b := range bars {
+			b.Close()
+		}
+		p.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		p.Wait(ctx)
+	})
+
+	add := func(g ttyprogress.Group, n int) {
+		bars = nil
+		for i := 1; i <= n; i++ {
+			b, err := ttyprogress.NewBar().SetTotal(10).SetWidth(10).PrependMessage(fmt.Sprintf("member%d", i)).Add(g)
+			Expect(err).To(Succeed())
+			b.Set(1)
+			bars = append(bars, b)
+		}
+	}
+
+	It("shows the most recently active members", func() {
+		g, err := ttyprogress.NewGroup[ttyprogress.Bar](ttyprogress.NewBar()).Collapse(1).Add(p)
+		Expect(err).To(Succeed())
+		add(g, 3)
+		Eventually(func() []string { return members(buf.Screen()) }).Should(Equal([]string{"member3"}))
+		bars[0].Set(2)
+		Eventually(func() []string { return members(buf.Screen()) }).Should(Equal([]string{"member1"}))
+		g.Close()
+	})
+
+	It("folds finished members", func() {
+		g, err := ttyprogress.NewGroup[ttyprogress.Bar](ttyprogress.NewBar()).FoldFinished().Add(p)
+		Expect(err).To(Succeed())
+		add(g, 3)
+		bars[0].Close()
+		Eventually(func() []string { return members(buf.Screen()) }).Should(Equal([]string{"✓ 1 done", "member2", "member3"}))
+		bars[1].Close()
+		Eventually(func() []string { return members(buf.Screen()) }).Should(Equal([]string{"✓ 2 done", "member3"}))
+		g.Close()
+	})
+
+	It("keeps failed members expanded", func() {
+		g, err := ttyprogress.NewGroup[ttyprogress.Bar](ttyprogress.NewBar()).FoldFinished().ExpandFailed().Add(p)
+		Expect(err).To(Succeed())
+		add(g, 3)
+		bars[0].Fail(fmt.Errorf("failed"))
+		bars[1].Close()
+		Eventually(func() []string { return members(buf.Screen()) }).Should(Equal([]string{"✓ 1 done", "member1", "member3"}))
+		g.Close()
+	})
+})
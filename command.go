@@ -0,0 +1,130 @@
+package ttyprogress
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync"
+
+	"github.com/mandelsoft/ttycolors"
+)
+
+// RunCommand starts the given command and streams its standard
+// and error output into a new element created from the given
+// definition, for example a Text or a TextSpinner.
+// If formats are given, the error output is shown with this
+// format.
+// The element is started together with the command and closed
+// when the command exits. If the command fails, the exit code is
+// shown as last line and the element fails with the error of the
+// command (an *exec.ExitError for a non-zero exit code).
+func RunCommand[E Text](c Container, def ElementDefinition[E], cmd *exec.Cmd, stderr ...ttycolors.FormatProvider) (E, error) {
+	elem, err := def.Add(c)
+	if err != nil {
+		return elem, err
+	}
+
+	out := &commandOutput{elem: elem}
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if len(stderr) > 0 {
+		if f, ok := any(elem).(formatter); ok {
+			cmd.Stderr = &commandErrorOutput{out, f, ttycolors.New(stderr...)}
+		}
+	}
+
+	elem.Start()
+	if err := cmd.Start(); err != nil {
+		out.message(err.Error())
+		elem.Fail(err)
+		return elem, err
+	}
+	go func() {
+		err := cmd.Wait()
+		if err == nil {
+			elem.Close()
+			return
+		}
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			out.message(fmt.Sprintf("exit code %d", exit.ExitCode()))
+		} else {
+			out.message(err.Error())
+		}
+		elem.Fail(err)
+	}()
+	return elem, nil
+}
+
+type formatter interface {
+	StringWith(f ttycolors.FormatProvider, seq ...any) ttycolors.String
+}
+
+// commandOutput forwards the output of a command
+// to an element.
+type commandOutput struct {
+	lock     sync.Mutex
+	elem     Text
+	unclosed bool
+}
+
+func (o *commandOutput) Write(data []byte) (int, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return o.write(data)
+}
+
+func (o *commandOutput) write(data []byte) (int, error) {
+	if len(data) > 0 {
+		o.unclosed = data[len(data)-1] != '\n'
+	}
+	return o.elem.Write(data)
+}
+
+// message adds a separate line to the output.
+func (o *commandOutput) message(m string) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.unclosed {
+		m = "\n" + m
+	}
+	o.write([]byte(m + "\n"))
+}
+
+// commandErrorOutput forwards the error output of a command
+// to an element. The format is applied separately to every line,
+// to keep the line handling of the element intact.
+type commandErrorOutput struct {
+	*commandOutput
+	formatter formatter
+	format    ttycolors.Format
+}
+
+func (o *commandErrorOutput) Write(data []byte) (int, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	var buf bytes.Buffer
+	start := 0
+	for i, b := range data {
+		if b == '\n' || b == '\r' {
+			o.formatLine(&buf, data[start:i])
+			buf.WriteByte(b)
+			start = i + 1
+		}
+	}
+	o.formatLine(&buf, data[start:])
+	if _, err := o.write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (o *commandErrorOutput) formatLine(buf *bytes.Buffer, line []byte) {
+	if len(line) > 0 {
+		buf.WriteString(o.formatter.StringWith(o.format, string(line)).String())
+	}
+}
@@ -0,0 +1,69 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttycolors"
+	"github.com/mandelsoft/ttyprogress"
+)
+
+var _ = Describe("Command Test Environment", func() {
+	var buf *bytes.Buffer
+	var p ttyprogress.Context
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		p = ttyprogress.For(buf)
+	})
+
+	run := func(cmd *exec.Cmd, stderr ...ttycolors.FormatProvider) (ttyprogress.Text, error) {
+		e, err := ttyprogress.RunCommand[ttyprogress.Text](p, ttyprogress.NewText(), cmd, stderr...)
+		p.Close()
+		p.Wait(context.Background())
+		return e, err
+	}
+
+	lines := func() []string {
+		return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	}
+
+	It("shows the exit code", func() {
+		e, err := run(exec.Command("sh", "-c", "echo out; echo err >&2; exit 3"))
+		Expect(err).To(Succeed())
+		Expect(e.GetError()).To(BeAssignableToTypeOf(&exec.ExitError{}))
+		Expect(lines()).To(Equal([]string{"out", "err", "exit code 3"}))
+	})
+
+	It("formats the error output per line", func() {
+		_, err := run(exec.Command("sh", "-c", "echo out; echo err1 >&2; echo err2 >&2; exit 3"), ttycolors.FmtRed)
+		Expect(err).To(Succeed())
+		Expect(lines()).To(ConsistOf("out", "err1", "err2", "exit code 3"))
+		Expect(lines()[3]).To(Equal("exit code 3"))
+	})
+
+	It("terminates an unclosed line", func() {
+		_, err := run(exec.Command("sh", "-c", "printf out; exit 3"))
+		Expect(err).To(Succeed())
+		Expect(lines()).To(Equal([]string{"out", "exit code 3"}))
+	})
+
+	It("closes the element", func() {
+		e, err := run(exec.Command("sh", "-c", "echo out"))
+		Expect(err).To(Succeed())
+		Expect(e.GetError()).To(BeNil())
+		Expect(lines()).To(Equal([]string{"out"}))
+	})
+
+	It("shows a start error", func() {
+		e, err := run(exec.Command("/nonexistent/command"))
+		Expect(err).NotTo(Succeed())
+		Expect(e.GetError()).To(MatchError(err))
+		Expect(lines()).To(Equal([]string{err.Error()}))
+	})
+})
@@ -7,8 +7,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/mandelsoft/goutils/optionutils"
 	"github.com/mandelsoft/ttycolors"
 	"github.com/mandelsoft/ttyprogress/blocks"
+	"github.com/mandelsoft/ttyprogress/ppi"
 	"github.com/mandelsoft/ttyprogress/specs"
 )
 
@@ -27,6 +29,40 @@ type Context interface {
 	IsColorsEnabled() bool
 	EnableColors(b ...bool) Context
 
+	// SetTheme sets the Theme used for all elements
+	// added to the Context, which do not provide an own Theme.
+	SetTheme(t *Theme) Context
+
+	// GetTheme returns the Theme set for the Context.
+	GetTheme() *Theme
+
+	// AlignColumns enables the column alignment for
+	// the elements added to the Context afterwards.
+	// The decorators and the visualization of all progress
+	// elements are padded to the maximum width used by
+	// the elements.
+	AlignColumns(b ...bool) Context
+
+	// SetOverflow sets the handling of lines exceeding
+	// the terminal width for all elements not configuring
+	// an own policy. The default is OverflowWrap.
+	SetOverflow(o Overflow) Context
+
+	// OnStall sets a handler called whenever a progress element
+	// added to the Context gets stalled, because there was no
+	// progress for its stall timeout (see SetStallTimeout).
+	// It is called again, if the element gets stalled again
+	// after some progress.
+	OnStall(h StallHandler) Context
+
+	// EnableKeyboard enables the interactive control of the
+	// display by the keyboard. The input terminal is put into raw mode
+	// until the Context is done. By default, the controlling
+	// terminal of the process is used. It is closed once the Context
+	// is done, whereas an explicitly given file is left open.
+	// The handled keys are described by blocks.Blocks.EnableKeyboard.
+	EnableKeyboard(in ...*os.File) error
+
 	// Blocks returns the underlying
 	// blocks.Blocks object used
 	// to display the progress elements.
@@ -56,10 +92,18 @@ type _progress struct {
 	blocks *blocks.Blocks
 	ticker *time.Ticker
 
-	elements []Element
-	closed   bool
+	elements  []Element
+	theme     *Theme
+	alignment *ppi.Alignment
+	closed    bool
+
+	onStall StallHandler
+	stalled map[ProgressElement]struct{}
 }
 
+// StallHandler is called for stalled progress elements.
+type StallHandler func(e ProgressElement, d time.Duration)
+
 var _ Container = (*_progress)(nil)
 
 // For creates a new Context, which manages a terminal line range
@@ -95,6 +139,67 @@ func (p *_progress) EnableColors(b ...bool) Context {
 	return p
 }
 
+func (p *_progress) SetTheme(t *Theme) Context {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.theme = t
+	return p
+}
+
+func (p *_progress) GetTheme() *Theme {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.theme
+}
+
+func (p *_progress) SetOverflow(o Overflow) Context {
+	p.Blocks().SetOverflow(o)
+	return p
+}
+
+func (p *_progress) AlignColumns(b ...bool) Context {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if optionutils.BoolOption(b...) {
+		if p.alignment == nil {
+			p.alignment = ppi.NewAlignment()
+		}
+	} else {
+		p.alignment = nil
+	}
+	return p
+}
+
+func (p *_progress) GetAlignment() *ppi.Alignment {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.alignment
+}
+
+func (p *_progress) OnStall(h StallHandler) Context {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.onStall = h
+	return p
+}
+
+func (p *_progress) EnableKeyboard(in ...*os.File) error {
+	if len(in) > 0 {
+		return p.blocks.EnableKeyboard(in[0])
+	}
+	// the terminal is owned by the Blocks object, which closes
+	// it after restoring the terminal state once it is done.
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return err
+	}
+	err = p.blocks.EnableKeyboard(tty, true)
+	if err != nil {
+		tty.Close()
+	}
+	return err
+}
+
 func (p *_progress) AddBlock(b *blocks.Block) error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -149,4 +254,29 @@ func (p *_progress) tick() {
 	if flush {
 		p.blocks.Flush()
 	}
+	p.checkStalled()
+}
+
+// checkStalled calls the stall handler for all progress
+// elements, which got stalled since the last check.
+func (p *_progress) checkStalled() {
+	p.lock.Lock()
+	h := p.onStall
+	p.lock.Unlock()
+	if h == nil {
+		return
+	}
+
+	stalled := map[ProgressElement]struct{}{}
+	for _, b := range p.blocks.Blocks() {
+		if e, ok := b.Payload().(ProgressElement); ok {
+			if d := e.TimeStalled(); d > 0 {
+				stalled[e] = struct{}{}
+				if _, ok := p.stalled[e]; !ok {
+					h(e, d)
+				}
+			}
+		}
+	}
+	p.stalled = stalled
 }
@@ -0,0 +1,204 @@
+package ttyprogress
+
+import (
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/object"
+	"github.com/mandelsoft/ttycolors"
+	"github.com/mandelsoft/ttyprogress/ppi"
+	"github.com/mandelsoft/ttyprogress/specs"
+)
+
+// ErrExpired is the error of countdowns failing on expiry.
+var ErrExpired = errors.New("deadline expired")
+
+// Countdown is a progress bar draining toward a deadline.
+type Countdown interface {
+	specs.CountdownInterface
+}
+
+type CountdownDefinition struct {
+	specs.CountdownDefinition[*CountdownDefinition]
+}
+
+// NewCountdown provides a definition for a countdown
+// expiring after the given time.
+func NewCountdown(total time.Duration) *CountdownDefinition {
+	d := &CountdownDefinition{}
+	d.CountdownDefinition = specs.NewCountdownDefinition[*CountdownDefinition](specs.NewSelf(d), total)
+	return d
+}
+
+// NewDeadline provides a definition for a countdown
+// expiring at the given point in time.
+func NewDeadline(t time.Time) *CountdownDefinition {
+	return NewCountdown(0).SetDeadline(t)
+}
+
+func (d *CountdownDefinition) Dup() *CountdownDefinition {
+	dup := &CountdownDefinition{}
+	dup.CountdownDefinition = d.CountdownDefinition.Dup(specs.NewSelf(dup))
+	return dup
+}
+
+func (d *CountdownDefinition) Add(c Container) (Countdown, error) {
+	return newCountdown(c, specs.InheritTheme(d, c))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type CountdownInterface = specs.CountdownInterface
+
+type _Countdown struct {
+	*ppi.BarBase[*_CountdownImpl, time.Duration]
+	elem *_CountdownImpl
+}
+
+func (c *_Countdown) Set(d time.Duration) bool {
+	defer c.elem.Lock()()
+
+	return c.elem.Protected().Set(d)
+}
+
+func (c *_Countdown) Current() time.Duration {
+	defer c.elem.Lock()()
+
+	return c.elem.Protected().Current()
+}
+
+func (c *_Countdown) Deadline() time.Time {
+	defer c.elem.Lock()()
+
+	return c.elem.Protected().Deadline()
+}
+
+func (c *_Countdown) IsExpired() bool {
+	defer c.elem.Lock()()
+
+	return c.elem.Protected().IsExpired()
+}
+
+func (c *_Countdown) TimeRemaining() (time.Duration, bool) {
+	defer c.elem.Lock()()
+
+	return c.elem.Protected().TimeRemaining()
+}
+
+func (c *_Countdown) CompletedPercent() float64 {
+	defer c.elem.Lock()()
+
+	return c.elem.Protected().CompletedPercent()
+}
+
+type _CountdownImpl struct {
+	*ppi.BarBaseImpl[*_CountdownImpl, time.Duration]
+
+	// deadline is the fixed expiry, if configured.
+	deadline      time.Time
+	warning       time.Duration
+	warningFormat ttycolors.Format
+	failOnExpiry  bool
+}
+
+// newCountdown returns a new progress bar draining
+// toward a deadline.
+func newCountdown(p Container, c specs.CountdownConfiguration) (Countdown, error) {
+	e := &_CountdownImpl{
+		deadline:     c.GetDeadline(),
+		failOnExpiry: c.IsFailOnExpiry(),
+	}
+	e.warning, e.warningFormat = c.GetWarning()
+	o := &_Countdown{elem: e}
+
+	b, s, err := ppi.NewBarBase[*_CountdownImpl, time.Duration](object.NewSelf[*_CountdownImpl, any](e, o), p, c, c.GetTotal(), nil, true)
+	if err != nil {
+		return nil, err
+	}
+	e.BarBaseImpl = s
+	o.BarBase = b
+	return o, nil
+}
+
+// Set sets the remaining time, for example, if a lease
+// is renewed. For a fixed deadline, the deadline is moved.
+func (b *_CountdownImpl) Set(d time.Duration) bool {
+	if b.IsClosed() {
+		return false
+	}
+	b.Start()
+	if !b.deadline.IsZero() {
+		b.deadline = time.Now().Add(d)
+	}
+	b.SetTotal(b.TimeElapsed() + d)
+	b.Touch()
+	b.Flush()
+	return true
+}
+
+// update adapts the total time to a fixed deadline.
+func (b *_CountdownImpl) update() {
+	if !b.deadline.IsZero() && b.IsStarted() && !b.IsClosed() {
+		b.SetTotal(b.TimeElapsed() + time.Until(b.deadline))
+	}
+}
+
+func (b *_CountdownImpl) Deadline() time.Time {
+	if !b.IsStarted() {
+		return time.Time{}
+	}
+	if !b.deadline.IsZero() {
+		return b.deadline
+	}
+	return time.Now().Add(b.Current())
+}
+
+func (b *_CountdownImpl) TimeRemaining() (time.Duration, bool) {
+	return b.Current(), true
+}
+
+// Current provides the remaining time.
+func (b *_CountdownImpl) Current() time.Duration {
+	if !b.IsStarted() {
+		if !b.deadline.IsZero() {
+			return max(time.Until(b.deadline), 0)
+		}
+		return b.Total()
+	}
+	b.update()
+	return max(b.Total()-b.TimeElapsed(), 0)
+}
+
+func (b *_CountdownImpl) IsExpired() bool {
+	return b.IsStarted() && b.Current() == 0
+}
+
+func (b *_CountdownImpl) IsFinished() bool {
+	return b.IsClosed() || b.IsExpired()
+}
+
+// CompletedPercent provides the percentage of the elapsed time.
+func (b *_CountdownImpl) CompletedPercent() float64 {
+	total := b.Total()
+	if total <= 0 {
+		return 0
+	}
+	return (float64(total-b.Current()) / float64(total)) * 100.00
+}
+
+// Visualize shows the bar filled with the remaining time.
+func (b *_CountdownImpl) Visualize() (ttycolors.String, bool) {
+	data, done := b.VisualizePercent(100 - b.CompletedPercent())
+	if b.warningFormat != nil && b.IsStarted() && !b.IsClosed() && b.Current() <= b.warning {
+		data = b.warningFormat.String(data)
+	}
+	return data, done
+}
+
+func (b *_CountdownImpl) Update() bool {
+	if b.failOnExpiry && b.IsExpired() && !b.IsClosed() {
+		b.Fail(ErrExpired)
+		return true
+	}
+	return b.BarBaseImpl.Update()
+}
@@ -0,0 +1,60 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+var _ = Describe("Countdown Test Environment", func() {
+	var p ttyprogress.Context
+
+	BeforeEach(func() {
+		p = ttyprogress.For(&bytes.Buffer{})
+	})
+
+	AfterEach(func() {
+		p.Close()
+	})
+
+	It("provides the elapsed time as completion", func() {
+		c, err := ttyprogress.NewCountdown(time.Hour).Add(p)
+		Expect(err).To(Succeed())
+		c.Start()
+		Expect(c.CompletedPercent()).To(BeNumerically("<", 1))
+		c.Close()
+	})
+
+	It("sets the remaining time", func() {
+		c, err := ttyprogress.NewCountdown(time.Hour).Add(p)
+		Expect(err).To(Succeed())
+		c.Start()
+		Expect(c.Set(time.Minute)).To(BeTrue())
+		Expect(c.Current()).To(BeNumerically("~", time.Minute, time.Second))
+		Expect(c.Set(2 * time.Hour)).To(BeTrue())
+		Expect(c.Current()).To(BeNumerically("~", 2*time.Hour, time.Second))
+		c.Close()
+	})
+
+	It("closes on expiry", func() {
+		c, err := ttyprogress.NewCountdown(50 * time.Millisecond).Add(p)
+		Expect(err).To(Succeed())
+		c.Start()
+		Eventually(c.IsClosed).Should(BeTrue())
+		Expect(c.IsExpired()).To(BeTrue())
+		Expect(c.GetError()).To(BeNil())
+		Expect(c.CompletedPercent()).To(Equal(100.0))
+	})
+
+	It("fails on expiry", func() {
+		c, err := ttyprogress.NewCountdown(50 * time.Millisecond).SetFailOnExpiry().Add(p)
+		Expect(err).To(Succeed())
+		c.Start()
+		Eventually(c.IsClosed).Should(BeTrue())
+		Expect(c.GetError()).To(MatchError(ttyprogress.ErrExpired))
+	})
+})
@@ -0,0 +1,435 @@
+package ttyprogress
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mandelsoft/goutils/sliceutils"
+	"github.com/mandelsoft/ttycolors"
+	"github.com/mandelsoft/ttyprogress/blocks"
+	"github.com/mandelsoft/ttyprogress/ppi"
+	"github.com/mandelsoft/ttyprogress/specs"
+)
+
+// DAGSteps can be used to visualize a set of steps with
+// dependencies, which may be executed concurrently.
+// Every step is represented by an own progress indicator.
+type DAGSteps interface {
+	specs.DAGStepsInterface
+}
+
+type DAGStep = specs.DAGStep
+
+// NewDAGStep describes a step executed after the given
+// predecessor steps are finished.
+func NewDAGStep[T Element](name string, definition ElementDefinition[T], after ...string) DAGStep {
+	return specs.NewDAGStep[T](name, definition, after...)
+}
+
+type DAGStepsDefinition struct {
+	specs.DAGStepsDefinition[*DAGStepsDefinition]
+}
+
+func NewDAGSteps(steps ...specs.DAGStep) *DAGStepsDefinition {
+	d := &DAGStepsDefinition{}
+	d.DAGStepsDefinition = specs.NewDAGStepsDefinition(specs.NewSelf(d), steps)
+	return d
+}
+
+func (d *DAGStepsDefinition) Dup() *DAGStepsDefinition {
+	dup := &DAGStepsDefinition{}
+	dup.DAGStepsDefinition = d.DAGStepsDefinition.Dup(specs.NewSelf(dup))
+	return dup
+}
+
+func (d *DAGStepsDefinition) Add(c Container) (DAGSteps, error) {
+	return newDAGSteps(c, specs.InheritTheme(d, c))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type dagState int
+
+const (
+	dagPending dagState = iota
+	dagRunning
+	dagDone
+	dagFailed
+)
+
+type _DAGStepsImpl struct {
+	lock sync.Mutex
+
+	steps   []DAGStep
+	names   []string
+	index   map[string]int
+	elems   []Element
+	state   []dagState
+	skipped string
+	started bool
+	err     error
+
+	main  Bar
+	group *ppi.GroupBase[Bar]
+}
+
+// newDAGSteps provides a group of step related progress indicators for a
+// given set of steps with dependencies. All steps are shown from the
+// beginning. A step is started as soon as all its predecessors are finished.
+func newDAGSteps(p Container, c specs.DAGStepsConfiguration) (DAGSteps, error) {
+	var err error
+
+	steps := c.GetSteps()
+	index, err := validateDAG(steps)
+	if err != nil {
+		return nil, err
+	}
+	names := blocks.AlignLeft(sliceutils.Transform(steps, func(step DAGStep) string { return step.Name() }))
+
+	n := &_DAGStepsImpl{
+		steps:   steps,
+		names:   names,
+		index:   index,
+		elems:   make([]Element, len(steps)),
+		state:   make([]dagState, len(steps)),
+		skipped: c.GetSkipped(),
+	}
+	n.group, n.main = ppi.NewGroupBase[Bar](p, c, func(b *ppi.GroupBase[Bar]) (Bar, specs.GroupNotifier, error) {
+		var d Bar
+		d, err = specs.TransferBarBaseConfig(NewBar().SetTotal(len(steps)), c).Add(b)
+		return d, &specs.VoidGroupNotifier{}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range steps {
+		n.elems[i], err = AddElement(n.group, s.LabeledDefinition(names[i]))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// validateDAG checks for unique step names, known predecessors
+// and cycles. It provides the index of the step names.
+func validateDAG(steps []DAGStep) (map[string]int, error) {
+	index := map[string]int{}
+	for i, s := range steps {
+		if _, ok := index[s.Name()]; ok {
+			return nil, fmt.Errorf("duplicate step %q", s.Name())
+		}
+		index[s.Name()] = i
+	}
+	for _, s := range steps {
+		for _, a := range s.After() {
+			if _, ok := index[a]; !ok {
+				return nil, fmt.Errorf("unknown predecessor %q for step %q", a, s.Name())
+			}
+		}
+	}
+
+	// 0: unvisited, 1: in progress, 2: visited
+	visited := make([]int, len(steps))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch visited[i] {
+		case 1:
+			return fmt.Errorf("dependency cycle for step %q", steps[i].Name())
+		case 2:
+			return nil
+		}
+		visited[i] = 1
+		for _, a := range steps[i].After() {
+			if err := visit(index[a]); err != nil {
+				return err
+			}
+		}
+		visited[i] = 2
+		return nil
+	}
+	for i := range steps {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return index, nil
+}
+
+func (n *_DAGStepsImpl) SetFinal(m string) {
+	n.main.SetFinal(m)
+}
+
+func (n *_DAGStepsImpl) SetProgressColor(f ttycolors.FormatProvider) {
+	n.main.SetProgressColor(f)
+}
+
+func (n *_DAGStepsImpl) SetVariable(name string, value any) {
+	n.main.SetVariable(name, value)
+}
+
+func (n *_DAGStepsImpl) GetVariable(name string) any {
+	return n.main.GetVariable(name)
+}
+
+func (n *_DAGStepsImpl) IsStarted() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.started
+}
+
+func (n *_DAGStepsImpl) IsClosed() bool {
+	return n.group.IsClosed()
+}
+
+func (n *_DAGStepsImpl) IsFinished() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.group.IsFinished()
+}
+
+func (n *_DAGStepsImpl) TimeElapsed() time.Duration {
+	return n.main.TimeElapsed()
+}
+
+func (n *_DAGStepsImpl) HideOnClose(b ...bool) {
+	n.group.HideOnClose(b...)
+}
+
+func (n *_DAGStepsImpl) Hide(b ...bool) {
+	n.group.Hide(b...)
+}
+
+func (n *_DAGStepsImpl) Flush() error {
+	return n.group.Flush()
+}
+
+// Start starts all steps without predecessors.
+func (n *_DAGStepsImpl) Start() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.started {
+		return
+	}
+	n.started = true
+	n.main.Start()
+	n.schedule()
+}
+
+// Reset is not supported, the steps
+// already executed cannot be reset.
+func (n *_DAGStepsImpl) Reset(keepElapsed ...bool) error {
+	return ErrNotResettable
+}
+
+func (n *_DAGStepsImpl) Retries() int {
+	return n.main.Retries()
+}
+
+func (n *_DAGStepsImpl) SetBackoff(d time.Duration) {
+	n.main.SetBackoff(d)
+}
+
+func (n *_DAGStepsImpl) Backoff() time.Duration {
+	return n.main.Backoff()
+}
+
+func (n *_DAGStepsImpl) Touch() {
+	n.main.Touch()
+}
+
+func (n *_DAGStepsImpl) LastUpdate() time.Time {
+	return n.main.LastUpdate()
+}
+
+func (n *_DAGStepsImpl) IsStalled() bool {
+	return n.main.IsStalled()
+}
+
+func (n *_DAGStepsImpl) TimeStalled() time.Duration {
+	return n.main.TimeStalled()
+}
+
+// Pause pauses the main progress indicator and
+// the elements of the running steps.
+func (n *_DAGStepsImpl) Pause() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.main.Pause()
+	for i, e := range n.elems {
+		if n.state[i] == dagRunning {
+			e.Pause()
+		}
+	}
+}
+
+func (n *_DAGStepsImpl) Resume() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.main.Resume()
+	for i, e := range n.elems {
+		if n.state[i] == dagRunning {
+			e.Resume()
+		}
+	}
+}
+
+func (n *_DAGStepsImpl) IsPaused() bool {
+	return n.main.IsPaused()
+}
+
+func (n *_DAGStepsImpl) Step(name string) Element {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if i, ok := n.index[name]; ok {
+		return n.elems[i]
+	}
+	return nil
+}
+
+func (n *_DAGStepsImpl) Running() []string {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	var result []string
+	for i, s := range n.steps {
+		if n.state[i] == dagRunning {
+			result = append(result, s.Name())
+		}
+	}
+	return result
+}
+
+// schedule starts all pending steps whose predecessors are finished.
+// Steps with failed predecessors are skipped. Only successfully
+// finished steps are counted by the main progress indicator.
+func (n *_DAGStepsImpl) schedule() {
+	for changed := true; changed; {
+		changed = false
+		for i, s := range n.steps {
+			if n.state[i] != dagPending {
+				continue
+			}
+			ready, failed := true, false
+			for _, a := range s.After() {
+				switch n.state[n.index[a]] {
+				case dagFailed:
+					failed = true
+				case dagDone:
+				default:
+					ready = false
+				}
+			}
+			switch {
+			case failed:
+				n.state[i] = dagFailed
+				n.skip(i)
+				changed = true
+			case ready:
+				n.state[i] = dagRunning
+				n.elems[i].Start()
+				go n.wait(i)
+			}
+		}
+	}
+	if !slices.ContainsFunc(n.state, func(s dagState) bool { return s == dagPending || s == dagRunning }) {
+		if n.err != nil {
+			n.group.Fail(n.err)
+		} else {
+			n.group.Close()
+		}
+	}
+}
+
+// skip closes the element of a step showing
+// it as skipped.
+func (n *_DAGStepsImpl) skip(i int) {
+	n.elems[i].SetFinal(n.group.Gap() + n.names[i] + " " + n.skipped)
+	n.elems[i].Close()
+}
+
+// wait waits for a running step to be finished
+// and schedules its successors.
+func (n *_DAGStepsImpl) wait(i int) {
+	e := n.elems[i]
+	e.Wait(nil)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.state[i] != dagRunning {
+		return
+	}
+	if err := e.GetError(); err != nil {
+		n.state[i] = dagFailed
+		if n.err == nil {
+			n.err = err
+		}
+	} else {
+		n.state[i] = dagDone
+		n.main.Incr()
+	}
+	n.schedule()
+}
+
+func (n *_DAGStepsImpl) Fail(err error) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.main.IsClosed() {
+		return os.ErrClosed
+	}
+	for i, e := range n.elems {
+		switch n.state[i] {
+		case dagRunning:
+			e.Fail(err)
+		case dagPending:
+			n.skip(i)
+		}
+		n.state[i] = dagFailed
+	}
+	return n.group.Fail(err)
+}
+
+func (n *_DAGStepsImpl) GetError() error {
+	return n.main.GetError()
+}
+
+func (n *_DAGStepsImpl) Close() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.main.IsClosed() {
+		return os.ErrClosed
+	}
+	for i, e := range n.elems {
+		switch n.state[i] {
+		case dagRunning:
+			n.state[i] = dagDone
+			e.Close()
+		case dagPending:
+			n.state[i] = dagFailed
+			n.skip(i)
+		}
+	}
+	n.group.Close()
+	if n.main.IsClosed() {
+		// closed by the group together with the last step.
+		return nil
+	}
+	return n.main.Close()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (n *_DAGStepsImpl) Wait(ctx context.Context) error {
+	return n.group.Wait(ctx)
+}
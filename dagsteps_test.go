@@ -0,0 +1,115 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+func step(name string, after ...string) ttyprogress.DAGStep {
+	return ttyprogress.NewDAGStep[ttyprogress.Spinner](name, ttyprogress.NewSpinner(), after...)
+}
+
+var _ = Describe("DAG Steps Test Environment", func() {
+	DescribeTable("validation",
+		func(msg string, steps ...ttyprogress.DAGStep) {
+			p := ttyprogress.For(&bytes.Buffer{})
+			defer p.Close()
+
+			_, err := ttyprogress.NewDAGSteps(steps...).Add(p)
+			if msg == "" {
+				Expect(err).To(Succeed())
+			} else {
+				Expect(err).To(MatchError(msg))
+			}
+		},
+		Entry("valid", "", step("a"), step("b", "a"), step("c", "a", "b")),
+		Entry("duplicate step", `duplicate step "a"`, step("a"), step("a")),
+		Entry("unknown predecessor", `unknown predecessor "x" for step "b"`, step("a"), step("b", "x")),
+		Entry("self reference", `dependency cycle for step "a"`, step("a", "a")),
+		Entry("cycle", `dependency cycle for step "a"`, step("a", "c"), step("b", "a"), step("c", "b")),
+	)
+
+	Context("execution", func() {
+		var buf *bytes.Buffer
+		var p ttyprogress.Context
+
+		BeforeEach(func() {
+			buf = &bytes.Buffer{}
+			p = ttyprogress.For(buf)
+		})
+
+		AfterEach(func() {
+			p.Close()
+		})
+
+		It("starts steps after their predecessors", func() {
+			d, err := ttyprogress.NewDAGSteps(step("a"), step("b"), step("c", "a", "b")).Add(p)
+			Expect(err).To(Succeed())
+			d.Start()
+			Expect(d.Running()).To(ConsistOf("a", "b"))
+			Expect(d.Step("c").IsStarted()).To(BeFalse())
+
+			d.Step("a").Close()
+			Consistently(d.Step("c").IsStarted, "50ms").Should(BeFalse())
+			d.Step("b").Close()
+			Eventually(d.Running).Should(ConsistOf("c"))
+
+			d.Step("c").Close()
+			Eventually(d.IsClosed).Should(BeTrue())
+			Expect(d.GetError()).To(BeNil())
+		})
+
+		It("skips the successors of a failed step", func() {
+			d, err := ttyprogress.NewDAGSteps(step("a"), step("b", "a"), step("c", "b"), step("d")).Add(p)
+			Expect(err).To(Succeed())
+			d.Start()
+
+			d.Step("a").Fail(errors.New("failed"))
+			Eventually(d.Step("c").IsClosed).Should(BeTrue())
+			Expect(d.Step("b").IsStarted()).To(BeFalse())
+			Expect(d.Step("c").IsStarted()).To(BeFalse())
+			Expect(d.IsClosed()).To(BeFalse())
+
+			d.Step("d").Close()
+			Eventually(d.IsClosed).Should(BeTrue())
+			Expect(d.GetError()).To(MatchError("failed"))
+
+			p.Close()
+			p.Wait(context.Background())
+			Expect(buf.String()).To(ContainSubstring("b skipped"))
+			Expect(buf.String()).To(ContainSubstring("c skipped"))
+		})
+
+		It("skips pending steps on close", func() {
+			d, err := ttyprogress.NewDAGSteps(step("a"), step("b", "a")).Add(p)
+			Expect(err).To(Succeed())
+			d.Start()
+			Expect(d.Close()).To(Succeed())
+
+			p.Close()
+			p.Wait(context.Background())
+			Expect(buf.String()).To(ContainSubstring("b skipped"))
+			Expect(buf.String()).NotTo(ContainSubstring("a skipped"))
+		})
+
+		It("decorates the steps of a definition once", func() {
+			def := ttyprogress.NewDAGSteps(step("a"))
+			for i := 0; i < 2; i++ {
+				d, err := def.Add(p)
+				Expect(err).To(Succeed())
+				d.Start()
+				d.Step("a").Close()
+				Eventually(d.IsClosed).Should(BeTrue())
+			}
+			p.Close()
+			p.Wait(context.Background())
+			Expect(buf.String()).NotTo(ContainSubstring("a a"))
+		})
+	})
+})
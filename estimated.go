@@ -29,7 +29,7 @@ func (d *EstimatedDefinition) Dup() *EstimatedDefinition {
 }
 
 func (d *EstimatedDefinition) Add(c Container) (Estimated, error) {
-	return newEstimated(c, d)
+	return newEstimated(c, specs.InheritTheme(d, c))
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -69,6 +69,9 @@ func (e *_Estimated) CompletedPercent() float64 {
 // _Estimated represents a progress bar
 type _EstimatedImpl struct {
 	*ppi.BarBaseImpl[*_EstimatedImpl, time.Duration]
+
+	history specs.History
+	name    string
 }
 
 // newEstimated returns a new progress bar
@@ -77,7 +80,15 @@ func newEstimated(p Container, c specs.EstimatedConfiguration) (Estimated, error
 	e := &_EstimatedImpl{}
 	o := &_Estimated{elem: e}
 
-	b, s, err := ppi.NewBarBase[*_EstimatedImpl, time.Duration](object.NewSelf[*_EstimatedImpl, any](e, o), p, c, 1, e.closer, true)
+	total := c.GetTotal()
+	e.history, e.name = c.GetHistory()
+	if e.history != nil {
+		if d, ok := e.history.Estimate(e.name); ok {
+			total = d
+		}
+	}
+
+	b, s, err := ppi.NewBarBase[*_EstimatedImpl, time.Duration](object.NewSelf[*_EstimatedImpl, any](e, o), p, c, total, e.closer, true)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +121,7 @@ func (b *_EstimatedImpl) Set(n time.Duration) bool {
 	if elapsed >= b.Total() {
 		b.SetTotal(b.Total() + 2*time.Second)
 	}
+	b.Touch()
 	b.Flush()
 	return true
 }
@@ -117,6 +129,9 @@ func (b *_EstimatedImpl) Set(n time.Duration) bool {
 func (b *_EstimatedImpl) closer() {
 	elapsed := b.TimeElapsed()
 	b.SetTotal(elapsed)
+	if b.history != nil && b.IsStarted() && b.GetError() == nil {
+		b.history.Record(b.name, elapsed)
+	}
 }
 
 func (b *_EstimatedImpl) IsFinished() bool {
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/mandelsoft/ttycolors"
+	"github.com/mandelsoft/ttyprogress"
+)
+
+func main() {
+	p := ttyprogress.For(os.Stdout)
+
+	lease, _ := ttyprogress.NewCountdown(10*time.Second).
+		SetWidth(ttyprogress.ReserveTerminalSize(40)).
+		SetPredefined(10).
+		PrependFunc(ttyprogress.Message("Lease")).
+		PrependRemaining().
+		SetWarning(3*time.Second, ttycolors.FmtRed).
+		SetFailOnExpiry().
+		Add(p)
+	window, _ := ttyprogress.NewDeadline(time.Now().Add(8 * time.Second)).
+		SetWidth(ttyprogress.ReserveTerminalSize(40)).
+		SetPredefined(10).
+		PrependFunc(ttyprogress.Message("Window")).
+		PrependRemaining().
+		Add(p)
+	lease.Start()
+	window.Start()
+	p.Close()
+
+	time.Sleep(5 * time.Second)
+	// renew the lease
+	lease.Set(10 * time.Second)
+	p.Wait(nil)
+}
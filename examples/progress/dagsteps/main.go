@@ -0,0 +1,56 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+func Step(n string, after ...string) ttyprogress.DAGStep {
+	return ttyprogress.NewDAGStep[ttyprogress.Bar](
+		n, ttyprogress.NewBar().SetTotal(100).
+			PrependElapsed().
+			AppendCompleted(),
+		after...,
+	)
+}
+
+func main() {
+	p := ttyprogress.For(os.Stdout)
+
+	steps := []ttyprogress.DAGStep{
+		Step("fetch"),
+		Step("lint"),
+		Step("build", "fetch"),
+		Step("test", "build", "lint"),
+		Step("docs", "fetch"),
+		Step("package", "test", "docs"),
+	}
+	dag, _ := ttyprogress.NewDAGSteps(steps...).
+		SetWidth(40).
+		PrependFunc(ttyprogress.Message("pipeline"), 0).
+		PrependElapsed().
+		AppendCompleted().
+		Add(p)
+
+	p.Close()
+
+	dag.Start()
+	for _, s := range steps {
+		go func() {
+			e := dag.Step(s.Name())
+			for !e.IsStarted() {
+				time.Sleep(time.Millisecond * 10)
+			}
+			for i := 0; i < 100; i++ {
+				time.Sleep(time.Millisecond * time.Duration(rand.Int()%50))
+				e.(ttyprogress.Bar).Incr()
+			}
+			e.Close()
+		}()
+	}
+
+	p.Wait(nil)
+}
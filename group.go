@@ -10,9 +10,24 @@ type Group interface {
 
 	Gap() string
 
+	// SetExpected sets the expected number of group members.
+	// It is used by the main progress indicator as long as
+	// fewer members have been added.
+	SetExpected(n int)
+
 	ppi.ProgressInterface
 }
 
+// MemberWeight provides the weight of a group member
+// used to aggregate the completion percent of a group.
+type MemberWeight = specs.MemberWeight
+
+// WeightByTotal weights group members providing
+// a total (like bars) by their total.
+func WeightByTotal(e Element) float64 {
+	return specs.WeightByTotal(e)
+}
+
 type GroupDefinition[E specs.ProgressInterface] struct {
 	specs.GroupDefinition[*GroupDefinition[E], E]
 }
@@ -25,12 +40,12 @@ func NewGroup[E specs.ProgressInterface](p specs.GroupProgressElementDefinition[
 
 func (d *GroupDefinition[E]) Dup() *GroupDefinition[E] {
 	dup := &GroupDefinition[E]{}
-	dup.GroupDefinition = d.GroupDefinition.Dup(specs.NewSelf(d))
+	dup.GroupDefinition = d.GroupDefinition.Dup(specs.NewSelf(dup))
 	return dup
 }
 
 func (d *GroupDefinition[E]) Add(c Container) (Group, error) {
-	return newGroup[E](c, d)
+	return newGroup[E](c, specs.InheritTheme(d, c))
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -50,5 +65,13 @@ func newGroup[E ppi.ProgressInterface](p Container, c specs.GroupConfiguration[E
 		}
 		return e, c.GetProgress().GetGroupNotifier(), err
 	})
+	if c.IsAggregateProgress() {
+		if err := g.GroupBase.AggregateProgress(c.GetMemberWeight()); err != nil {
+			return nil, err
+		}
+	}
+	if n := c.GetExpected(); n > 0 {
+		g.SetExpected(n)
+	}
 	return g, nil
 }
@@ -0,0 +1,162 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+// barState records the state of a group bar
+// whenever it is rendered.
+type barState struct {
+	lock  sync.Mutex
+	state string
+}
+
+func (s *barState) record(e ttyprogress.ElementState) any {
+	if b, ok := e.(interface {
+		Current() int
+		Total() int
+	}); ok {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.state = fmt.Sprintf("%d/%d", b.Current(), b.Total())
+	}
+	return ""
+}
+
+func (s *barState) State() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.state
+}
+
+var _ = Describe("Group Test Environment", func() {
+	var p ttyprogress.Context
+	var main *barState
+	var added []ttyprogress.Bar
+
+	BeforeEach(func() {
+		p = ttyprogress.For(&bytes.Buffer{})
+		main = &barState{}
+		added = nil
+	})
+
+	AfterEach(func() {
+		for _, b := range added {
+			b.Close()
+		}
+		p.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		p.Wait(ctx)
+	})
+
+	add := func(g ttyprogress.Group, totals ...int) []ttyprogress.Bar {
+		var bars []ttyprogress.Bar
+		for _, t := range totals {
+			b, err := ttyprogress.NewBar().SetTotal(t).Add(g)
+			Expect(err).To(Succeed())
+			bars = append(bars, b)
+		}
+		added = append(added, bars...)
+		return bars
+	}
+
+	It("counts the finished members", func() {
+		g, err := ttyprogress.NewGroup[ttyprogress.Bar](ttyprogress.NewBar().AppendFunc(main.record)).Add(p)
+		Expect(err).To(Succeed())
+		bars := add(g, 10, 10, 10)
+		Eventually(main.State).Should(Equal("0/3"))
+		bars[0].Close()
+		Eventually(main.State).Should(Equal("1/3"))
+		bars[1].Close()
+		bars[2].Close()
+		g.Close()
+		Eventually(main.State).Should(Equal("3/3"))
+	})
+
+	It("aggregates the member progress", func() {
+		g, err := ttyprogress.NewGroup[ttyprogress.Bar](ttyprogress.NewBar().AppendFunc(main.record)).
+			AggregateProgress().Add(p)
+		Expect(err).To(Succeed())
+		bars := add(g, 30, 10)
+		bars[0].Set(15)
+		Eventually(main.State).Should(Equal("250/1000"))
+		bars[0].Close()
+		bars[1].Close()
+		g.Close()
+		Eventually(main.State).Should(Equal("1000/1000"))
+	})
+
+	It("weights the members by their total", func() {
+		g, err := ttyprogress.NewGroup[ttyprogress.Bar](ttyprogress.NewBar().AppendFunc(main.record)).
+			AggregateProgress(ttyprogress.WeightByTotal).Add(p)
+		Expect(err).To(Succeed())
+		bars := add(g, 30, 10)
+		bars[0].Set(15)
+		Eventually(main.State).Should(Equal("375/1000"))
+		bars[0].Close()
+		bars[1].Close()
+		g.Close()
+		Eventually(main.State).Should(Equal("1000/1000"))
+	})
+
+	Context("expected members", func() {
+		It("uses the expected number before members are added", func() {
+			g, err := ttyprogress.NewGroup[ttyprogress.Bar](ttyprogress.NewBar().AppendFunc(main.record)).
+				SetExpected(3).Add(p)
+			Expect(err).To(Succeed())
+			bars := add(g, 10)
+			Eventually(main.State).Should(Equal("0/3"))
+			bars[0].Close()
+			Eventually(main.State).Should(Equal("1/3"))
+			add(g, 10, 10, 10)
+			Eventually(main.State).Should(Equal("1/4"))
+			g.Close()
+		})
+
+		It("uses the expected number set after members are added", func() {
+			g, err := ttyprogress.NewGroup[ttyprogress.Bar](ttyprogress.NewBar().AppendFunc(main.record)).Add(p)
+			Expect(err).To(Succeed())
+			add(g, 10, 10)
+			Eventually(main.State).Should(Equal("0/2"))
+			g.SetExpected(4)
+			Eventually(main.State).Should(Equal("0/4"))
+			g.SetExpected(1)
+			Eventually(main.State).Should(Equal("0/2"))
+			g.Close()
+		})
+
+		It("adjusts the total to fewer finished members", func() {
+			g, err := ttyprogress.NewGroup[ttyprogress.Bar](ttyprogress.NewBar().AppendFunc(main.record)).
+				SetExpected(4).Add(p)
+			Expect(err).To(Succeed())
+			bars := add(g, 10, 10)
+			bars[0].Close()
+			bars[1].Close()
+			Eventually(main.State).Should(Equal("2/4"))
+			g.Close()
+			Eventually(main.State).Should(Equal("2/2"))
+		})
+
+		It("counts missing members with 0% for aggregated progress", func() {
+			g, err := ttyprogress.NewGroup[ttyprogress.Bar](ttyprogress.NewBar().AppendFunc(main.record)).
+				AggregateProgress().SetExpected(4).Add(p)
+			Expect(err).To(Succeed())
+			bars := add(g, 10)
+			bars[0].Set(5)
+			Eventually(main.State).Should(Equal("125/1000"))
+			bars[0].Close()
+			g.Close()
+			Eventually(main.State).Should(Equal("1000/1000"))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package ttyprogress_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+var _ = Describe("Heartbeat Test Environment", func() {
+	var buf *syncBuffer
+	var p ttyprogress.Context
+
+	BeforeEach(func() {
+		buf = &syncBuffer{}
+		p = ttyprogress.For(buf)
+	})
+
+	AfterEach(func() {
+		p.Close()
+		p.Wait(context.Background())
+	})
+
+	// phase provides the actually shown phase of the spinner.
+	phase := func() string {
+		for _, l := range buf.Screen() {
+			if strings.HasPrefix(l, "spinner ") {
+				return strings.TrimPrefix(l, "spinner ")
+			}
+		}
+		return ""
+	}
+
+	It("advances the phase only after a beat", func() {
+		s, err := ttyprogress.NewSpinner().SetSimplePhases("1", "2", "3", "4").
+			SetHeartbeat().PrependMessage("spinner").Add(p)
+		Expect(err).To(Succeed())
+		s.Start()
+		Eventually(phase).Should(BeElementOf("1", "2", "3", "4"))
+		first := phase()
+		Consistently(phase, 200*time.Millisecond).Should(Equal(first))
+
+		s.Beat()
+		Eventually(phase).ShouldNot(Equal(first))
+		second := phase()
+		Consistently(phase, 200*time.Millisecond).Should(Equal(second))
+
+		s.Touch()
+		Eventually(phase).ShouldNot(Equal(second))
+		s.Close()
+	})
+
+	It("advances the phase without beats in regular mode", func() {
+		s, err := ttyprogress.NewSpinner().SetSimplePhases("1", "2", "3", "4").
+			PrependMessage("spinner").Add(p)
+		Expect(err).To(Succeed())
+		s.Start()
+		Eventually(phase).Should(BeElementOf("1", "2", "3", "4"))
+		first := phase()
+		Eventually(phase).ShouldNot(Equal(first))
+		s.Close()
+	})
+})
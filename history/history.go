@@ -0,0 +1,128 @@
+// Package history provides a file based store for the durations
+// of recurring progress elements. It can be used to seed the
+// expected durations of Estimated, Steps and NestedSteps elements
+// with the durations of previous runs.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Smoothing is the default weight of a new duration
+// for the exponential smoothing of the estimates.
+const Smoothing = 0.3
+
+// Entry is the recorded information for a key.
+type Entry struct {
+	Estimate time.Duration `json:"estimate"`
+	Count    int           `json:"count"`
+}
+
+// Store is a history of durations persisted in a local file.
+// Recorded durations are kept in memory until the history
+// is persisted with Save.
+type Store struct {
+	lock      sync.Mutex
+	path      string
+	smoothing float64
+	entries   map[string]Entry
+}
+
+// New provides a Store for the given file path. Existing
+// entries are loaded from the file. An optional smoothing
+// factor (0 < s <= 1) can be given to weight new durations.
+func New(path string, smoothing ...float64) (*Store, error) {
+	s := &Store{
+		path:      path,
+		smoothing: Smoothing,
+		entries:   map[string]Entry{},
+	}
+	if len(smoothing) > 0 && smoothing[0] > 0 && smoothing[0] <= 1 {
+		s.smoothing = smoothing[0]
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Estimate provides the smoothed duration recorded for a key.
+func (s *Store) Estimate(key string) (time.Duration, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.entries[key]
+	return e.Estimate, ok
+}
+
+// Get provides the entry for a key.
+func (s *Store) Get(key string) (Entry, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.entries[key]
+	return e, ok
+}
+
+// Record adds an actual duration for a key.
+func (s *Store) Record(key string, d time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.entries[key]
+	if ok {
+		e.Estimate = time.Duration(s.smoothing*float64(d) + (1-s.smoothing)*float64(e.Estimate))
+	} else {
+		e.Estimate = d
+	}
+	e.Count++
+	s.entries[key] = e
+}
+
+// Save persists the history. The file is replaced
+// atomically to keep it consistent for concurrent processes.
+func (s *Store) Save() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Chmod(0o644)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress/history"
+)
+
+var _ = Describe("History Test Environment", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "history", "durations.json")
+	})
+
+	It("provides no estimate for unknown keys", func() {
+		s, err := history.New(path)
+		Expect(err).To(Succeed())
+		_, ok := s.Estimate("build")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("smoothes recorded durations", func() {
+		s, err := history.New(path, 0.5)
+		Expect(err).To(Succeed())
+		s.Record("build", 10*time.Second)
+		d, _ := s.Estimate("build")
+		Expect(d).To(Equal(10 * time.Second))
+		s.Record("build", 20*time.Second)
+		d, _ = s.Estimate("build")
+		Expect(d).To(Equal(15 * time.Second))
+	})
+
+	It("persists the history", func() {
+		s, err := history.New(path)
+		Expect(err).To(Succeed())
+		s.Record("build", 10*time.Second)
+
+		n, err := history.New(path)
+		Expect(err).To(Succeed())
+		_, ok := n.Get("build")
+		Expect(ok).To(BeFalse())
+
+		Expect(s.Save()).To(Succeed())
+		Expect(os.ReadDir(filepath.Dir(path))).To(HaveLen(1))
+		s, err = history.New(path)
+		Expect(err).To(Succeed())
+		e, ok := s.Get("build")
+		Expect(ok).To(BeTrue())
+		Expect(e).To(Equal(history.Entry{Estimate: 10 * time.Second, Count: 1}))
+	})
+})
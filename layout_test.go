@@ -0,0 +1,51 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+var _ = Describe("Layout Test Environment", func() {
+	render := func(def *ttyprogress.BarDefinition) string {
+		buf := &bytes.Buffer{}
+		p := ttyprogress.For(buf)
+		bar, err := def.SetTotal(10).SetWidth(10).Add(p)
+		Expect(err).To(Succeed())
+		bar.SetVariable("v", "value")
+		bar.Start()
+		bar.Set(5)
+		bar.Close()
+		p.Close()
+		p.Wait(context.Background())
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		return lines[len(lines)-1]
+	}
+
+	It("provides the fields", func() {
+		Expect(render(ttyprogress.NewBar().
+			SetNamedFunc("Message", ttyprogress.Message("hello")).
+			PrependMessage("pre").
+			AppendMessage("post").
+			SetLayout("{{.Message}}|{{.Prepend}}|{{.Bar}}|{{.Percent}}|{{.Append}}|{{.Vars.v}}|{{.Unknown}}"))).
+			To(Equal("hello|pre|[=====>----]| 50%|post|value|"))
+	})
+
+	It("pads values", func() {
+		Expect(render(ttyprogress.NewBar().
+			SetNamedFunc("Message", ttyprogress.Message("hello")).
+			SetLayout("[{{.Message | left 7}}][{{.Message | right 7}}][{{.Message | center 8}}][{{.Unknown | left 2}}]"))).
+			To(Equal("[hello  ][  hello][ hello  ][  ]"))
+	})
+
+	It("reports errors", func() {
+		Expect(render(ttyprogress.NewBar().
+			SetLayout(`{{template "missing"}}`))).
+			To(HavePrefix("layout error: "))
+	})
+})
@@ -38,7 +38,7 @@ func (d *LineBarDefinition) GetGroupNotifier() specs.GroupNotifier {
 }
 
 func (d *LineBarDefinition) Add(c Container) (LineBar, error) {
-	return newLineBar(c, d)
+	return newLineBar(c, specs.InheritTheme(d, c))
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -108,6 +108,7 @@ func (b *_LineBarImpl) Set(n int) bool {
 		n = b.Protected().Total()
 	}
 	b.current = n
+	b.Touch()
 	b.Protected().Flush()
 	return true
 }
@@ -122,10 +123,15 @@ func (b *_LineBarImpl) Incr() bool {
 
 	n := b.current + 1
 	b.current = n
+	b.Touch()
 	b.Protected().Flush()
 	return true
 }
 
+func (b *_LineBarImpl) ResetProgress() {
+	b.current = 0
+}
+
 func (b *_LineBarImpl) IsFinished() bool {
 	return b.current == b.Protected().Total()
 }
@@ -0,0 +1,135 @@
+package ttyprogress
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/goutils/general"
+	"github.com/mandelsoft/ttycolors"
+	"github.com/mandelsoft/ttyprogress/blocks"
+	"github.com/mandelsoft/ttyprogress/specs"
+	"github.com/mandelsoft/ttyprogress/units"
+)
+
+// Line describes a line of a text element passed to
+// line prefix and format functions.
+type Line = specs.Line
+
+// LinePrefixFunc provides a prefix for a line of a text element.
+type LinePrefixFunc = specs.LinePrefixFunc
+
+// LineFormatFunc provides a format for a line of a text element.
+type LineFormatFunc = specs.LineFormatFunc
+
+// LineFilterFunc selects lines of a text element.
+type LineFilterFunc = specs.LineFilterFunc
+
+// ElapsedLinePrefix provides a line prefix showing the time
+// since the first line of the element has been written.
+// It uses the given format, or the duration format of the
+// element (see Theme.DurationFormat). By default, the seconds
+// are shown with one decimal.
+func ElapsedLinePrefix(f ...units.DurationFormat) LinePrefixFunc {
+	format := general.Optional(f...)
+	return func(l Line) string {
+		df := format
+		if df == nil {
+			df = l.DurationFormat
+		}
+		if df == nil {
+			return fmt.Sprintf("[%6.1fs] ", l.Elapsed.Seconds())
+		}
+		return "[" + df(l.Elapsed) + "] "
+	}
+}
+
+// TimestampLinePrefix provides a line prefix showing the
+// wall-clock time a line has been written.
+// The default layout is time.TimeOnly.
+func TimestampLinePrefix(layout ...string) LinePrefixFunc {
+	f := general.OptionalDefaulted(time.TimeOnly, layout...)
+	return func(l Line) string {
+		return l.Time.Format(f) + " "
+	}
+}
+
+// LineLevel describes a severity tag for lines
+// matching a regular expression.
+type LineLevel struct {
+	Tag     string
+	Pattern *regexp.Regexp
+}
+
+// NewLineLevel provides a LineLevel for the given tag and pattern.
+// It panics if the pattern is invalid.
+func NewLineLevel(tag string, pattern string) LineLevel {
+	return LineLevel{tag, regexp.MustCompile(pattern)}
+}
+
+// LevelLinePrefix provides a line prefix showing the tag of
+// the first level matching the line. The tags are aligned
+// to the same width.
+func LevelLinePrefix(levels ...LineLevel) LinePrefixFunc {
+	width := 0
+	for _, l := range levels {
+		width = max(width, blocks.StringWidth(l.Tag))
+	}
+	return func(l Line) string {
+		for _, e := range levels {
+			if e.Pattern.MatchString(l.Text) {
+				return e.Tag + strings.Repeat(" ", width-blocks.StringWidth(e.Tag)+1)
+			}
+		}
+		return strings.Repeat(" ", width+1)
+	}
+}
+
+// LinePrefixes combines multiple line prefixes.
+func LinePrefixes(prefixes ...LinePrefixFunc) LinePrefixFunc {
+	return func(l Line) string {
+		s := ""
+		for _, p := range prefixes {
+			s += p(l)
+		}
+		return s
+	}
+}
+
+// MatchLineFormat provides a line format for lines
+// matching the given regular expression.
+// It panics if the pattern is invalid.
+func MatchLineFormat(pattern string, f ...ttycolors.FormatProvider) LineFormatFunc {
+	exp := regexp.MustCompile(pattern)
+	format := ttycolors.New(f...)
+	return func(l Line) ttycolors.FormatProvider {
+		if exp.MatchString(l.Text) {
+			return format
+		}
+		return nil
+	}
+}
+
+// LineFormats combines multiple line formats.
+// The first format provided for a line is used.
+func LineFormats(formats ...LineFormatFunc) LineFormatFunc {
+	return func(l Line) ttycolors.FormatProvider {
+		for _, f := range formats {
+			if r := f(l); r != nil {
+				return r
+			}
+		}
+		return nil
+	}
+}
+
+// MatchLines provides a line filter selecting lines
+// matching the given regular expression.
+// It panics if the pattern is invalid.
+func MatchLines(pattern string) LineFilterFunc {
+	exp := regexp.MustCompile(pattern)
+	return func(l Line) bool {
+		return exp.MatchString(l.Text)
+	}
+}
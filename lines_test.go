@@ -0,0 +1,31 @@
+package ttyprogress_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+	"github.com/mandelsoft/ttyprogress/units"
+)
+
+var _ = Describe("Line Prefix Test Environment", func() {
+	line := ttyprogress.Line{Text: "line", Elapsed: 65*time.Second + 250*time.Millisecond}
+
+	It("shows the elapsed time", func() {
+		Expect(ttyprogress.ElapsedLinePrefix()(line)).To(Equal("[  65.2s] "))
+	})
+
+	It("uses the format of the element", func() {
+		l := line
+		l.DurationFormat = units.Compact
+		Expect(ttyprogress.ElapsedLinePrefix()(l)).To(Equal("[1m05s] "))
+	})
+
+	It("uses the given format", func() {
+		l := line
+		l.DurationFormat = units.Compact
+		Expect(ttyprogress.ElapsedLinePrefix(units.Clock)(l)).To(Equal("[00:01:05] "))
+	})
+})
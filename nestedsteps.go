@@ -2,13 +2,16 @@ package ttyprogress
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/mandelsoft/goutils/sliceutils"
-	"github.com/mandelsoft/goutils/stringutils"
 	"github.com/mandelsoft/ttycolors"
+	"github.com/mandelsoft/ttyprogress/blocks"
 	"github.com/mandelsoft/ttyprogress/ppi"
 	"github.com/mandelsoft/ttyprogress/specs"
 )
@@ -21,6 +24,10 @@ type NestedSteps interface {
 
 type NestedStep = specs.NestedStep
 
+// ErrStepPassed is returned by NestedSteps.GoTo for a step
+// preceding the current step.
+var ErrStepPassed = errors.New("step already passed")
+
 func NewNestedStep[T Element](name string, definition ElementDefinition[T]) NestedStep {
 	return specs.NewNestedStep[T](name, definition)
 }
@@ -42,16 +49,20 @@ func (d *NestedStepsDefinition) Dup() *NestedStepsDefinition {
 }
 
 func (d *NestedStepsDefinition) Add(c Container) (NestedSteps, error) {
-	return newNestedSteps(c, d)
+	return newNestedSteps(c, specs.InheritTheme(d, c))
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 
 type _NestedStepsImpl struct {
 	lock sync.Mutex
+	// nlock guards the step names used by the step decorators.
+	nlock sync.RWMutex
 
-	steps []NestedStep
-	names []string
+	steps   []NestedStep
+	names   []string
+	labeled []bool
+	skipped string
 
 	main  nestedMain
 	group *ppi.GroupBase[nestedMain]
@@ -61,7 +72,9 @@ type _NestedStepsImpl struct {
 type nestedMain interface {
 	ppi.ProgressInterface
 	Current() int
+	Set(n int) bool
 	Incr() bool
+	SetSteps(steps ...string)
 	IsFinished() bool
 }
 
@@ -74,21 +87,45 @@ func newNestedSteps(p Container, c specs.NestedStepsConfiguration) (NestedSteps,
 	var err error
 
 	steps := c.GetSteps()
-	names := stringutils.AlignLeft(sliceutils.Transform(steps, func(step NestedStep) string { return step.Name() }), ' ')
+	names := blocks.AlignLeft(stepNames(steps))
 
-	n := &_NestedStepsImpl{steps: steps, names: names}
+	n := &_NestedStepsImpl{steps: steps, names: names, labeled: make([]bool, len(steps)), skipped: c.GetSkipped()}
+	weights, durations := stepWeights(steps)
 	n.group, n.main = ppi.NewGroupBase[nestedMain](p, c, func(b *ppi.GroupBase[nestedMain]) (nestedMain, specs.GroupNotifier, error) {
 		var d nestedMain
-		if c.IsShowStepTitle() {
-			d, err = specs.TransferBarBaseConfig(NewSteps(names...), c).Add(b)
-		} else {
-			d, err = specs.TransferBarBaseConfig(NewBar().SetTotal(len(steps)), c).Add(b)
+		// a Steps indicator is used in any case to support weighted steps.
+		def := NewSteps(names...).SetWeights(weights...).SetDurations(durations...)
+		if h, name := c.GetHistory(); h != nil {
+			def.UseHistory(h, name)
+		}
+		d, err = specs.TransferBarBaseConfig(def, c).Add(b)
+		if err == nil && !c.IsShowStepTitle() {
+			d.(*_Steps).hideTitle()
 		}
 		return d, &specs.VoidGroupNotifier{}, nil
 	})
 	return n, err
 }
 
+func stepNames(steps []NestedStep) []string {
+	return sliceutils.Transform(steps, func(step NestedStep) string { return step.Name() })
+}
+
+// stepWeights provides the weights and durations of the steps.
+// If no step is weighted, nil is returned.
+func stepWeights(steps []NestedStep) ([]float64, []time.Duration) {
+	var weights []float64
+	var durations []time.Duration
+
+	if slices.ContainsFunc(steps, func(s NestedStep) bool { return s.Weight() > 0 }) {
+		weights = sliceutils.Transform(steps, func(s NestedStep) float64 { return s.Weight() })
+	}
+	if slices.ContainsFunc(steps, func(s NestedStep) bool { return s.Duration() > 0 }) {
+		durations = sliceutils.Transform(steps, func(s NestedStep) time.Duration { return s.Duration() })
+	}
+	return weights, durations
+}
+
 func (n *_NestedStepsImpl) SetFinal(m string) {
 	n.main.SetFinal(m)
 }
@@ -135,10 +172,74 @@ func (n *_NestedStepsImpl) Flush() error {
 	return n.group.Flush()
 }
 
+// Reset is not supported, the steps
+// already executed cannot be reset.
+func (n *_NestedStepsImpl) Reset(keepElapsed ...bool) error {
+	return ErrNotResettable
+}
+
+func (n *_NestedStepsImpl) Retries() int {
+	return n.main.Retries()
+}
+
+func (n *_NestedStepsImpl) SetBackoff(d time.Duration) {
+	n.main.SetBackoff(d)
+}
+
+func (n *_NestedStepsImpl) Backoff() time.Duration {
+	return n.main.Backoff()
+}
+
+func (n *_NestedStepsImpl) Touch() {
+	n.main.Touch()
+}
+
+func (n *_NestedStepsImpl) LastUpdate() time.Time {
+	return n.main.LastUpdate()
+}
+
+func (n *_NestedStepsImpl) IsStalled() bool {
+	return n.main.IsStalled()
+}
+
+func (n *_NestedStepsImpl) TimeStalled() time.Duration {
+	return n.main.TimeStalled()
+}
+
+// Pause pauses the main progress indicator and
+// the element of the actual step.
+func (n *_NestedStepsImpl) Pause() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.main.Pause()
+	if n.cur != nil {
+		n.cur.Pause()
+	}
+}
+
+func (n *_NestedStepsImpl) Resume() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.main.Resume()
+	if n.cur != nil {
+		n.cur.Resume()
+	}
+}
+
+func (n *_NestedStepsImpl) IsPaused() bool {
+	return n.main.IsPaused()
+}
+
 func (n *_NestedStepsImpl) Start() {
 	n.lock.Lock()
 	defer n.lock.Unlock()
 
+	n.start()
+}
+
+func (n *_NestedStepsImpl) start() {
 	if n.main.IsStarted() {
 		return
 	}
@@ -152,19 +253,116 @@ func (n *_NestedStepsImpl) Current() Element {
 	return n.cur
 }
 
+func (n *_NestedStepsImpl) name(i int) string {
+	n.nlock.RLock()
+	defer n.nlock.RUnlock()
+	return n.names[i]
+}
+
+// definition provides the definition for the given step.
+// It is decorated with the (aligned) step name once.
+func (n *_NestedStepsImpl) definition(i int) ElementDefinition[Element] {
+	def := n.steps[i].Definition()
+	if !n.labeled[i] {
+		specs.PrependFunc(def, func(ElementState) any { return n.name(i) }, 0)
+		n.labeled[i] = true
+	}
+	return def
+}
+
 func (n *_NestedStepsImpl) add() (Element, error) {
 	var err error
-	cur := n.main.Current()
-	step := n.steps[cur]
-	def := step.Definition()
-	specs.PrependFunc(def, Message(n.names[cur]), 0)
-	n.cur, err = AddElement(n.group, def)
-	if err != nil {
+	n.cur, err = AddElement(n.group, n.definition(n.main.Current()))
+	if err == nil {
 		n.cur.Start()
 	}
 	return n.cur, err
 }
 
+// skip closes the element of a step showing
+// it as skipped.
+func (n *_NestedStepsImpl) skip(e Element, i int) {
+	e.SetFinal(n.group.Gap() + n.name(i) + " " + n.skipped)
+	e.Close()
+}
+
+// next continues with the given step or closes the group
+// if there are no more steps.
+func (n *_NestedStepsImpl) next(i int) (Element, error) {
+	n.cur = nil
+	n.main.Set(i)
+	if !n.main.IsFinished() {
+		return n.add()
+	}
+	n.group.Close()
+	return nil, nil
+}
+
+func (n *_NestedStepsImpl) AddStep(step NestedStep) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.group.IsClosed() {
+		return os.ErrClosed
+	}
+	n.steps = append(n.steps, step)
+	n.labeled = append(n.labeled, false)
+
+	n.nlock.Lock()
+	n.names = blocks.AlignLeft(stepNames(n.steps))
+	n.nlock.Unlock()
+
+	n.main.(*_Steps).setWeights(stepWeights(n.steps))
+	n.main.SetSteps(n.names...)
+	return n.group.Flush()
+}
+
+func (n *_NestedStepsImpl) Skip() (Element, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.group.IsClosed() {
+		return nil, os.ErrClosed
+	}
+	n.start()
+	if n.cur != nil {
+		n.skip(n.cur, n.main.Current())
+	}
+	return n.next(n.main.Current() + 1)
+}
+
+func (n *_NestedStepsImpl) GoTo(name string) (Element, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.group.IsClosed() {
+		return nil, os.ErrClosed
+	}
+	idx := slices.IndexFunc(n.steps, func(s NestedStep) bool { return s.Name() == name })
+	if idx < 0 {
+		return nil, fmt.Errorf("unknown step %q", name)
+	}
+	n.start()
+	cur := n.main.Current()
+	if idx == cur {
+		return n.cur, nil
+	}
+	if idx < cur {
+		return nil, fmt.Errorf("step %q: %w", name, ErrStepPassed)
+	}
+	if n.cur != nil {
+		n.cur.Close()
+	}
+	for i := cur + 1; i < idx; i++ {
+		e, err := AddElement(n.group, n.definition(i))
+		if err != nil {
+			return nil, err
+		}
+		n.skip(e, i)
+	}
+	return n.next(idx)
+}
+
 func (n *_NestedStepsImpl) Incr() (Element, error) {
 	n.lock.Lock()
 	defer n.lock.Unlock()
@@ -181,6 +379,28 @@ func (n *_NestedStepsImpl) Incr() (Element, error) {
 	return nil, nil
 }
 
+func (n *_NestedStepsImpl) Fail(err error) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.main.IsClosed() {
+		return os.ErrClosed
+	}
+	if n.cur != nil {
+		n.cur.Fail(err)
+	}
+	n.cur = nil
+	// the main indicator is closed by the group
+	// as soon as all steps are closed.
+	err = n.main.Fail(err)
+	n.group.Close()
+	return err
+}
+
+func (n *_NestedStepsImpl) GetError() error {
+	return n.main.GetError()
+}
+
 func (n *_NestedStepsImpl) Close() error {
 	n.lock.Lock()
 	defer n.lock.Unlock()
@@ -193,6 +413,10 @@ func (n *_NestedStepsImpl) Close() error {
 	}
 	n.cur = nil
 	n.group.Close()
+	if n.main.IsClosed() {
+		// closed by the group
+		return nil
+	}
 	return n.main.Close()
 }
 
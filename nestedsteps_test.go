@@ -0,0 +1,101 @@
+package ttyprogress_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+func nestedStep(name string) ttyprogress.NestedStep {
+	return ttyprogress.NewNestedStep[ttyprogress.Spinner](name, ttyprogress.NewSpinner())
+}
+
+var _ = Describe("Nested Steps Test Environment", func() {
+	var buf *syncBuffer
+	var p ttyprogress.Context
+	var n ttyprogress.NestedSteps
+
+	BeforeEach(func() {
+		var err error
+		buf = &syncBuffer{}
+		p = ttyprogress.For(buf)
+		n, err = ttyprogress.NewNestedSteps(nestedStep("a"), nestedStep("b"), nestedStep("c")).Add(p)
+		Expect(err).To(Succeed())
+		n.Start()
+	})
+
+	// steps closes the context and provides
+	// the final lines of the steps.
+	steps := func() []string {
+		p.Close()
+		p.Wait(context.Background())
+		var r []string
+		for _, l := range buf.Screen()[1:] {
+			r = append(r, strings.Join(strings.Fields(l), " "))
+		}
+		return r
+	}
+
+	It("executes the steps", func() {
+		Expect(n.Incr()).NotTo(BeNil())
+		Expect(n.Incr()).NotTo(BeNil())
+		Expect(n.Incr()).To(BeNil())
+		Expect(n.IsClosed()).To(BeTrue())
+		Expect(steps()).To(Equal([]string{"a done", "b done", "c done"}))
+	})
+
+	It("adds steps", func() {
+		Expect(n.AddStep(nestedStep("added"))).To(Succeed())
+		for i := 0; i < 3; i++ {
+			Expect(n.Incr()).NotTo(BeNil())
+		}
+		Expect(n.Incr()).To(BeNil())
+		Expect(n.AddStep(nestedStep("late"))).To(MatchError(os.ErrClosed))
+		Expect(steps()).To(Equal([]string{"a done", "b done", "c done", "added done"}))
+	})
+
+	It("skips the current step", func() {
+		Expect(n.Skip()).NotTo(BeNil())
+		Expect(n.Incr()).NotTo(BeNil())
+		Expect(n.Skip()).To(BeNil())
+		Expect(n.IsClosed()).To(BeTrue())
+		Expect(steps()).To(Equal([]string{"a skipped", "b done", "c skipped"}))
+	})
+
+	It("goes to a later step", func() {
+		Expect(n.GoTo("c")).NotTo(BeNil())
+		Expect(n.Incr()).To(BeNil())
+		Expect(steps()).To(Equal([]string{"a done", "b skipped", "c done"}))
+	})
+
+	It("rejects going back to a passed step", func() {
+		Expect(n.Incr()).NotTo(BeNil())
+		_, err := n.GoTo("a")
+		Expect(err).To(MatchError(ttyprogress.ErrStepPassed))
+		_, err = n.GoTo("unknown")
+		Expect(err).To(MatchError(`unknown step "unknown"`))
+		Expect(n.Incr()).NotTo(BeNil())
+		Expect(n.Incr()).To(BeNil())
+		Expect(steps()).To(Equal([]string{"a done", "b done", "c done"}))
+	})
+
+	It("closes the steps", func() {
+		Expect(n.Close()).To(Succeed())
+		Expect(n.IsClosed()).To(BeTrue())
+		Expect(n.Close()).To(MatchError(os.ErrClosed))
+		steps()
+	})
+
+	It("fails the steps", func() {
+		err := fmt.Errorf("broken")
+		Expect(n.Fail(err)).To(Succeed())
+		Expect(n.GetError()).To(Equal(err))
+		steps()
+	})
+})
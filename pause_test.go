@@ -0,0 +1,62 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+var _ = Describe("Pause Test Environment", func() {
+	var p ttyprogress.Context
+
+	BeforeEach(func() {
+		p = ttyprogress.For(&bytes.Buffer{})
+	})
+
+	AfterEach(func() {
+		p.Close()
+	})
+
+	It("excludes the paused time from the elapsed time", func() {
+		bar, err := ttyprogress.NewBar().Add(p)
+		Expect(err).To(Succeed())
+		bar.Start()
+		time.Sleep(20 * time.Millisecond)
+
+		bar.Pause()
+		Expect(bar.IsPaused()).To(BeTrue())
+		elapsed := bar.TimeElapsed()
+		time.Sleep(100 * time.Millisecond)
+		Expect(bar.TimeElapsed()).To(BeNumerically("~", elapsed, 10*time.Millisecond))
+
+		bar.Resume()
+		Expect(bar.IsPaused()).To(BeFalse())
+		time.Sleep(20 * time.Millisecond)
+		Expect(bar.TimeElapsed()).To(BeNumerically(">=", elapsed+20*time.Millisecond))
+		Expect(bar.TimeElapsed()).To(BeNumerically("<", elapsed+90*time.Millisecond))
+		bar.Close()
+	})
+
+	It("excludes the paused time when closed while paused", func() {
+		bar, err := ttyprogress.NewBar().Add(p)
+		Expect(err).To(Succeed())
+		bar.Start()
+		bar.Pause()
+		time.Sleep(100 * time.Millisecond)
+		bar.Close()
+		Expect(bar.IsPaused()).To(BeFalse())
+		Expect(bar.TimeElapsed()).To(BeNumerically("<", 50*time.Millisecond))
+	})
+
+	It("ignores pausing elements not started", func() {
+		bar, err := ttyprogress.NewBar().Add(p)
+		Expect(err).To(Succeed())
+		bar.Pause()
+		Expect(bar.IsPaused()).To(BeFalse())
+		bar.Close()
+	})
+})
@@ -0,0 +1,83 @@
+package ppi
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/mandelsoft/ttyprogress/blocks"
+	"github.com/mandelsoft/ttyprogress/specs"
+)
+
+var ErrNoAggregation = errors.New("main progress indicator does not support aggregation")
+
+// aggregation is the aggregation related part of a GroupBase.
+type aggregation struct {
+	weight     specs.MemberWeight
+	aggregator specs.ProgressAggregator
+	// ticker is a hidden block used to get ticks from the Context.
+	ticker *blocks.Block
+}
+
+// aggregationTicker is the payload of the hidden ticker block. It is
+// ticked by the Context to update the aggregated completion percent.
+type aggregationTicker struct {
+	update func(final bool)
+}
+
+func (a *aggregationTicker) Tick() bool {
+	a.update(false)
+	return false
+}
+
+// AggregateProgress lets the main progress indicator show the (weighted)
+// average completion percent of the group members. It must be called
+// before members are added.
+func (g *GroupBase[T]) AggregateProgress(weight specs.MemberWeight) error {
+	a, ok := g.notifier.(specs.ProgressAggregator)
+	if !ok {
+		return ErrNoAggregation
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	anchor := g.blocks[0]
+	t := blocks.NewBlock(1)
+	t.SetPayload(&aggregationTicker{g.updateAggregation})
+	t.Hide()
+	if err := anchor.Blocks().AppendBlock(t, anchor); err != nil {
+		return err
+	}
+	g.aggregation = &aggregation{weight: weight, aggregator: a, ticker: t}
+	return nil
+}
+
+func (g *GroupBase[T]) updateAggregation(final bool) {
+	g.lock.RLock()
+	members := slices.Clone(g.blocks[1:])
+	expected := g.expected
+	g.lock.RUnlock()
+
+	// members expected but not yet added count with 0%.
+	total, sum := float64(max(expected-len(members), 0)), 0.0
+	for _, b := range members {
+		e, _ := b.Payload().(specs.ElementInterface)
+		w := 1.0
+		if g.aggregation.weight != nil && e != nil {
+			w = g.aggregation.weight(e)
+		}
+		p := 0.0
+		if b.IsClosed() {
+			p = 100
+		} else if c, ok := e.(specs.CompletedPercent); ok {
+			p = c.CompletedPercent()
+		}
+		total += w
+		sum += w * p
+	}
+	percent := 0.0
+	if total > 0 {
+		percent = sum / total
+	}
+	g.aggregation.aggregator.Aggregate(g.main, percent, final)
+}
@@ -0,0 +1,130 @@
+package ppi
+
+import (
+	"slices"
+	"sync"
+)
+
+// Aligned is the optional interface of containers
+// requesting a column alignment for their elements.
+type Aligned interface {
+	GetAlignment() *Alignment
+}
+
+// Columns describes the widths of the column slots of
+// an element: the prepended decorators, the visualization
+// and the appended decorators.
+type Columns struct {
+	Prepend       []int
+	Visualization int
+	Append        []int
+}
+
+func (c Columns) clone() Columns {
+	return Columns{slices.Clone(c.Prepend), c.Visualization, slices.Clone(c.Append)}
+}
+
+func (c Columns) equal(o Columns) bool {
+	return slices.Equal(c.Prepend, o.Prepend) && c.Visualization == o.Visualization && slices.Equal(c.Append, o.Append)
+}
+
+type aligned struct {
+	gap     int
+	columns Columns
+}
+
+// Alignment keeps track of the widths of the columns
+// (decorators and visualization) of a set of sibling
+// elements. It is used to align the columns of all
+// elements like a table.
+// Columns are matched by their slot, the n-th prepended
+// decorator, the visualization or the n-th appended decorator,
+// so that elements with different numbers of decorators
+// still show their visualization in the same column.
+// The gap of an element is accounted to the first slot
+// used by the set of elements.
+// Closed elements keep their widths, because their lines
+// stay visible and are realigned as long as they are not
+// finally written.
+// Whenever the column widths change, the generation
+// is incremented, which can be used by the elements
+// to detect the need for a re-rendering.
+type Alignment struct {
+	lock       sync.Mutex
+	widths     map[any]aligned
+	max        Columns
+	generation int
+}
+
+func NewAlignment() *Alignment {
+	return &Alignment{widths: map[any]aligned{}}
+}
+
+// Update registers the gap and the actual column widths of an element
+// and provides the effective column widths and the actual generation.
+// The effective widths include the gap in the first slot.
+func (a *Alignment) Update(e any, gap int, widths Columns) (Columns, int) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.widths[e] = aligned{gap, widths.clone()}
+	a.update()
+	return a.max.clone(), a.generation
+}
+
+// Remove removes an element from the alignment.
+func (a *Alignment) Remove(e any) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	delete(a.widths, e)
+	a.update()
+}
+
+// Generation provides the actual generation of the
+// column widths.
+func (a *Alignment) Generation() int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.generation
+}
+
+func (a *Alignment) update() {
+	prepend := 0
+	for _, w := range a.widths {
+		prepend = max(prepend, len(w.columns.Prepend))
+	}
+
+	var m Columns
+	if prepend > 0 {
+		m.Prepend = make([]int, prepend)
+	}
+	for _, w := range a.widths {
+		for i, c := range w.columns.Prepend {
+			if i == 0 {
+				c += w.gap
+			}
+			m.Prepend[i] = max(m.Prepend[i], c)
+		}
+		if prepend > 0 && len(w.columns.Prepend) == 0 {
+			m.Prepend[0] = max(m.Prepend[0], w.gap)
+		}
+		c := w.columns.Visualization
+		if prepend == 0 {
+			c += w.gap
+		}
+		m.Visualization = max(m.Visualization, c)
+		for i, c := range w.columns.Append {
+			if i >= len(m.Append) {
+				m.Append = append(m.Append, c)
+			} else {
+				m.Append[i] = max(m.Append[i], c)
+			}
+		}
+	}
+	if !m.equal(a.max) {
+		a.max = m
+		a.generation++
+	}
+}
@@ -94,6 +94,13 @@ func runeBytes(r rune) []byte {
 }
 
 func (b *BarBaseImpl[T, V]) Visualize() (ttycolors.String, bool) {
+	return b.VisualizePercent(b.Protected().CompletedPercent())
+}
+
+// VisualizePercent renders the bar filled up to the given
+// percentage. It can be used by derived elements filling
+// the bar with another value than the completion.
+func (b *BarBaseImpl[T, V]) VisualizePercent(percent float64) (ttycolors.String, bool) {
 	var buf bytes.Buffer
 
 	if !b.IsStarted() {
@@ -104,7 +111,7 @@ func (b *BarBaseImpl[T, V]) Visualize() (ttycolors.String, bool) {
 		if b.config.LeftEnd != ' ' {
 			buf.Write(runeBytes(b.config.LeftEnd))
 		}
-		completedWidth := int(float64(b.width) * (b.Protected().CompletedPercent() / 100.00))
+		completedWidth := int(float64(b.width) * (percent / 100.00))
 		// add fill and empty bits
 
 		fill := string(b.config.Fill)
@@ -0,0 +1,150 @@
+package ppi
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/mandelsoft/ttyprogress/blocks"
+	"github.com/mandelsoft/ttyprogress/specs"
+)
+
+// collapseState is the collapse related part of a GroupState.
+type collapseState struct {
+	policy specs.CollapsePolicy
+
+	// summary is the block showing the number of folded members.
+	summary *blocks.Block
+	// folded indicates that the group is folded by its parent group.
+	folded bool
+	// count is the number of members shown by the summary.
+	count int
+}
+
+// collapser is the payload of the summary block. It is
+// ticked by the Context to adapt the folding to the activity
+// of the group members. The folding is only recalculated
+// if the state of the members changed since the last tick.
+type collapser struct {
+	group *GroupState
+	last  memberState
+}
+
+// memberState describes the state of the group members
+// relevant for the folding.
+type memberState struct {
+	members  int
+	closed   int
+	failed   int
+	activity uint64
+}
+
+func (c *collapser) Tick() bool {
+	treeLock.Lock()
+	defer treeLock.Unlock()
+
+	state := c.group.memberState()
+	if state != c.last {
+		c.last = state
+		c.group.updateMembers()
+	}
+	return false
+}
+
+// memberState provides the actual state of the group members.
+// The activity is only considered, if the number of shown
+// unfinished members is limited.
+func (g *GroupState) memberState() memberState {
+	var s memberState
+	for _, b := range g.children {
+		if b == g.summary {
+			continue
+		}
+		s.members++
+		if b.IsClosed() {
+			s.closed++
+			if b.IsFailed() {
+				s.failed++
+			}
+		} else if g.policy.Active > 0 {
+			s.activity += b.Activity()
+		}
+	}
+	return s
+}
+
+// addSummary adds the summary block directly after the anchor block.
+func (g *GroupState) addSummary(anchor *blocks.Block) error {
+	gap := g.Gap()
+	if g.memberGap != nil {
+		gap = g.memberGap()
+	}
+	s := blocks.NewBlock(1)
+	s.SetPayload(&collapser{group: g})
+	s.SetGap(gap)
+	s.Fold()
+	if err := anchor.Blocks().AppendBlock(s, anchor); err != nil {
+		return err
+	}
+	anchor.SetNext(s)
+	g.summary = s
+	g.addTreeChild(s)
+	return nil
+}
+
+// updateCollapse folds the group members according to the
+// collapse policy. Members of groups folded by their parent
+// are always folded.
+func (g *GroupState) updateCollapse() {
+	var open []*blocks.Block
+
+	count := 0
+	for _, b := range g.children {
+		if b == g.summary {
+			continue
+		}
+		if b.IsClosed() {
+			fold := g.policy.FoldFinished && !(g.policy.ExpandFailed && b.IsFailed())
+			if fold {
+				count++
+			}
+			g.foldMember(b, g.folded || fold)
+		} else {
+			open = append(open, b)
+		}
+	}
+
+	shown := open
+	if g.policy.Active > 0 && len(open) > g.policy.Active {
+		shown = slices.Clone(open)
+		// most recently active first, prefer later members on equal activity
+		slices.Reverse(shown)
+		slices.SortStableFunc(shown, func(a, b *blocks.Block) int {
+			return cmp.Compare(b.Activity(), a.Activity())
+		})
+		shown = shown[:g.policy.Active]
+	}
+	for _, b := range open {
+		g.foldMember(b, g.folded || !slices.Contains(shown, b))
+	}
+
+	if g.summary != nil {
+		if count > 0 && count != g.count {
+			g.summary.Reset()
+			fmt.Fprintf(g.summary, specs.EffectiveTheme(g.theme).Folded+"\n", count)
+		}
+		g.count = count
+		g.summary.Fold(g.folded || count == 0)
+	}
+}
+
+// foldMember folds a member. Nested groups are folded
+// completely.
+func (g *GroupState) foldMember(b *blocks.Block, fold bool) {
+	b.Fold(fold)
+	if n := g.nodes[b]; n != nil && n.folded != fold {
+		n.folded = fold
+		n.updateCollapse()
+		n.updateTree()
+	}
+}
@@ -24,12 +24,16 @@ type ElementImpl interface {
 }
 
 type (
-	TitleFormatProvider = specs.TitleFormatProvider
-	ViewFormatProvider  = specs.ViewFormatProvider
-	TitleLineProvider   = specs.TitleLineProvider
-	GapProvider         = specs.GapProvider
-	FollowupGapProvider = specs.FollowupGapProvider
-	VariableProvider    = specs.VariableProvider
+	TitleFormatProvider    = specs.TitleFormatProvider
+	ViewFormatProvider     = specs.ViewFormatProvider
+	LinePrefixProvider     = specs.LinePrefixProvider
+	DurationFormatProvider = specs.DurationFormatProvider
+	LineFormatProvider     = specs.LineFormatProvider
+	ViewFilterProvider     = specs.ViewFilterProvider
+	TitleLineProvider      = specs.TitleLineProvider
+	GapProvider            = specs.GapProvider
+	FollowupGapProvider    = specs.FollowupGapProvider
+	VariableProvider       = specs.VariableProvider
 )
 
 type ElemBase[I ElementImpl] struct {
@@ -60,12 +64,42 @@ func (b *ElemBase[I]) IsFinished() bool {
 	return b.elem.Protected().IsFinished()
 }
 
+func (b *ElemBase[I]) Pause() {
+	defer b.elem.Lock()()
+
+	b.elem.Protected().Pause()
+}
+
+func (b *ElemBase[I]) Resume() {
+	defer b.elem.Lock()()
+
+	b.elem.Protected().Resume()
+}
+
+func (b *ElemBase[I]) IsPaused() bool {
+	defer b.elem.RLock()()
+
+	return b.elem.Protected().IsPaused()
+}
+
 func (b *ElemBase[I]) Close() error {
 	defer b.elem.Lock()()
 
 	return b.elem.Protected().Close()
 }
 
+func (b *ElemBase[I]) Fail(err error) error {
+	defer b.elem.Lock()()
+
+	return b.elem.Protected().Fail(err)
+}
+
+func (b *ElemBase[I]) GetError() error {
+	defer b.elem.Lock()()
+
+	return b.elem.Protected().GetError()
+}
+
 func (b *ElemBase[I]) Hide(f ...bool) {
 	defer b.elem.Lock()()
 
@@ -84,9 +118,17 @@ func (b *ElemBase[I]) Flush() error {
 	return b.elem.Protected().Flush()
 }
 
-func (b *ElemBase[I]) Wait(ctx context.Context) error {
+// StringWith provides a formatted string according to the
+// color settings of the context the element is shown in.
+func (b *ElemBase[I]) StringWith(f ttycolors.FormatProvider, seq ...any) ttycolors.String {
 	defer b.elem.Lock()()
 
+	return b.elem.StringWith(f, seq...)
+}
+
+// Wait waits until the element is closed. It does not lock
+// the element, otherwise it could not be closed while waiting.
+func (b *ElemBase[I]) Wait(ctx context.Context) error {
 	return b.elem.Protected().Wait(ctx)
 }
 
@@ -114,8 +156,13 @@ type ElemBaseImpl[I ElementImpl] struct {
 	// timeStarted is time progress began.
 	timeStarted time.Time
 	timeElapsed time.Duration
+	// timePaused is the accumulated duration of finished pauses.
+	timePaused time.Duration
+	// pausedSince is the start of the actual pause.
+	pausedSince time.Time
 
 	closed bool
+	err    error
 }
 
 // var _ ElementImpl = (*ElemBaseImpl[ElementImpl])(nil)
@@ -145,6 +192,9 @@ func NewElemBase[I ElementImpl](self object.Self[I, any], p Container, c specs.E
 	if c.GetHide() {
 		b.Hide(c.GetHide())
 	}
+	if c.GetOverflow() != blocks.OverflowDefault {
+		b.SetOverflow(c.GetOverflow())
+	}
 
 	// determine base gaps from parent
 	pgap := ""
@@ -184,6 +234,18 @@ func NewElemBase[I ElementImpl](self object.Self[I, any], p Container, c specs.E
 	if t, ok := c.(ViewFormatProvider); ok && t.GetViewFormat() != nil {
 		b.SetViewFormat(t.GetViewFormat())
 	}
+	if t, ok := c.(LinePrefixProvider); ok && t.GetLinePrefix() != nil {
+		b.SetLinePrefixFunc(t.GetLinePrefix())
+	}
+	if t, ok := c.(DurationFormatProvider); ok && t.GetDurationFormat() != nil {
+		b.SetDurationFormat(t.GetDurationFormat())
+	}
+	if t, ok := c.(LineFormatProvider); ok && t.GetLineFormat() != nil {
+		b.SetLineFormatFunc(t.GetLineFormat())
+	}
+	if t, ok := c.(ViewFilterProvider); ok && t.GetViewFilter() != nil {
+		b.SetViewFilterFunc(t.GetViewFilter())
+	}
 
 	if err := p.AddBlock(b); err != nil {
 		return nil, nil, err
@@ -275,11 +337,70 @@ func (b *ElemBaseImpl[I]) close() error {
 	if b.closed {
 		return os.ErrClosed
 	}
+	b.resume()
 	b.closed = true
-	b.timeElapsed = time.Since(b.timeStarted)
+	b.timeElapsed = time.Since(b.timeStarted) - b.timePaused
 	return nil
 }
 
+// Pause pauses a started element. The paused time
+// is excluded from the elapsed time.
+func (b *ElemBaseImpl[I]) Pause() {
+	if b.closed || !b.IsStarted() || b.IsPaused() {
+		return
+	}
+	b.pausedSince = time.Now()
+	b.Protected().Flush()
+}
+
+func (b *ElemBaseImpl[I]) Resume() {
+	if b.resume() {
+		b.Protected().Flush()
+	}
+}
+
+func (b *ElemBaseImpl[I]) resume() bool {
+	if !b.IsPaused() {
+		return false
+	}
+	b.timePaused += time.Since(b.pausedSince)
+	b.pausedSince = time.Time{}
+	return true
+}
+
+// reset restarts the time measurement of a started
+// element, if keepElapsed is not set.
+func (b *ElemBaseImpl[I]) reset(keepElapsed bool) {
+	if keepElapsed || !b.IsStarted() {
+		return
+	}
+	b.timeStarted = time.Now()
+	b.timePaused = 0
+	if b.IsPaused() {
+		b.pausedSince = b.timeStarted
+	}
+}
+
+func (b *ElemBaseImpl[I]) IsPaused() bool {
+	return !b.pausedSince.IsZero()
+}
+
+func (b *ElemBaseImpl[I]) Fail(err error) error {
+	if b.closed {
+		return os.ErrClosed
+	}
+	if err == nil {
+		err = types.ErrFailed
+	}
+	b.err = err
+	b.block.Fail()
+	return b.Protected().Close()
+}
+
+func (b *ElemBaseImpl[I]) GetError() error {
+	return b.err
+}
+
 func (b *ElemBaseImpl[I]) IsClosed() bool {
 	return b.closed
 }
@@ -305,5 +426,8 @@ func (b *ElemBaseImpl[I]) TimeElapsed() time.Duration {
 	if b.closed {
 		return b.timeElapsed
 	}
-	return time.Since(b.timeStarted)
+	if b.IsPaused() {
+		return b.pausedSince.Sub(b.timeStarted) - b.timePaused
+	}
+	return time.Since(b.timeStarted) - b.timePaused
 }
@@ -29,8 +29,16 @@ type GroupState struct {
 	gap         string
 	followup    string
 	hideOnClose bool
+	theme       *specs.Theme
+	alignment   *Alignment
 	closer      func()
 
+	treeState
+	collapseState
+
+	// memberGap provides the gap used for group members.
+	memberGap func() string
+
 	blocks        []*blocks.Block
 	blockinfo     map[*blocks.Block]bool
 	notifyCreator func(b *blocks.Block) func()
@@ -44,13 +52,25 @@ func NewGroupState(p Container, c specs.GroupBaseConfiguration, closer ...func()
 		gap:         c.GetGap(),
 		followup:    c.GetFollowUpGap(),
 		hideOnClose: c.IsHideOnClose(),
+		theme:       c.GetTheme(),
 		blocks:      []*blocks.Block{},
 		blockinfo:   map[*blocks.Block]bool{},
 		closer:      general.Optional(closer...),
+		treeState: treeState{
+			tree:       c.IsTree(),
+			connectors: c.GetTreeConnectors(),
+			nodes:      map[*blocks.Block]*GroupState{},
+		},
+		collapseState: collapseState{
+			policy: c.GetCollapsePolicy(),
+		},
 	}
 	if g.followup == "" {
 		g.followup = g.gap
 	}
+	if c.IsAlignColumns() {
+		g.alignment = NewAlignment()
+	}
 	if pg, ok := p.(Gapped); ok {
 		g.pgap = pg.Gap()
 	}
@@ -82,6 +102,9 @@ func (g *GroupState) AddBlock(b *blocks.Block) error {
 		if g.hideOnClose {
 			b.HideOnClose()
 		}
+		if n, ok := g.parent.(treeNode); ok {
+			n.addTreeNode(b, g)
+		}
 	} else {
 		n := g.blocks[0]
 		// find last block og group (including nested groups)
@@ -94,10 +117,15 @@ func (g *GroupState) AddBlock(b *blocks.Block) error {
 			b.RegisterCloser(g.notifyCreator(b))
 		}
 		b.RegisterCloser(g.finishBlock)
+		b.RegisterCloser(g.UpdateMembers)
+		g.addTreeChild(b)
 	}
 	if b != nil {
 		g.blocks = append(g.blocks, b)
 		g.blocks[0].SetNext(b)
+		if len(g.blocks) == 1 && g.policy.IsActive() {
+			return g.addSummary(b)
+		}
 	}
 	return nil
 }
@@ -119,16 +147,38 @@ func (g *GroupState) finishBlock() {
 	if g.closer != nil {
 		g.closer()
 	}
+	if g.summary != nil && !g.summary.IsClosed() {
+		g.UpdateMembers()
+		g.summary.Close()
+	}
 	if !g.blocks[0].IsClosed() {
 		g.blocks[0].Close()
 	}
 }
 
+// GetTheme provides the Theme used for elements
+// added to the group.
+func (g *GroupState) GetTheme() *specs.Theme {
+	return g.theme
+}
+
+// GetAlignment provides the column alignment used for
+// the elements of the group, if enabled.
+func (g *GroupState) GetAlignment() *Alignment {
+	return g.alignment
+}
+
 func (g *GroupState) Gap() string {
+	if g.tree {
+		return ""
+	}
 	return g.pgap + g.gap
 }
 
 func (g *GroupState) FollowUpGap() string {
+	if g.tree {
+		return ""
+	}
 	return g.pgap + g.followup
 }
 
@@ -196,13 +246,17 @@ type GroupBase[T ProgressInterface] struct {
 
 	main     T
 	notifier specs.GroupNotifier
+
+	aggregation *aggregation
+	expected    int
 }
 
 func NewGroupBase[T ProgressInterface](p Container, c specs.GroupBaseConfiguration, main func(base *GroupBase[T]) (T, specs.GroupNotifier, error)) (*GroupBase[T], T) {
 	g := &GroupBase[T]{
 		GroupState: *NewGroupState(p, c),
 	}
-	g.notifyCreator = g.notifyCreator
+	g.notifyCreator = g.createNotifier
+	g.memberGap = g.Gap
 	g.closer = g.closeMain
 
 	if m, n, err := main(g); err != nil {
@@ -226,10 +280,16 @@ func (g *GroupBase[T]) AddBlock(b *blocks.Block) error {
 }
 
 func (g *GroupBase[T]) Gap() string {
+	if g.tree {
+		return ""
+	}
 	return g.pgap + g.followup
 }
 
 func (g *GroupBase[T]) createNotifier(b *blocks.Block) func() {
+	if g.aggregation != nil {
+		return func() {}
+	}
 	g.notifier.Add(g.main, b)
 	return func() { g.notifier.Done(g.main, b) }
 }
@@ -270,6 +330,85 @@ func (g *GroupBase[T]) IsFinished() bool {
 	return g.main.IsFinished()
 }
 
+// Reset resets the main element of the group.
+func (g *GroupBase[T]) Reset(keepElapsed ...bool) error {
+	return g.main.Reset(keepElapsed...)
+}
+
+func (g *GroupBase[T]) Retries() int {
+	return g.main.Retries()
+}
+
+func (g *GroupBase[T]) SetBackoff(d time.Duration) {
+	g.main.SetBackoff(d)
+}
+
+func (g *GroupBase[T]) Backoff() time.Duration {
+	return g.main.Backoff()
+}
+
+func (g *GroupBase[T]) Touch() {
+	g.main.Touch()
+}
+
+func (g *GroupBase[T]) LastUpdate() time.Time {
+	return g.main.LastUpdate()
+}
+
+// IsStalled reports whether the main element
+// of the group is stalled.
+func (g *GroupBase[T]) IsStalled() bool {
+	return g.main.IsStalled()
+}
+
+func (g *GroupBase[T]) TimeStalled() time.Duration {
+	return g.main.TimeStalled()
+}
+
+// Pause pauses the main element of the group.
+// The group members are paused separately.
+func (g *GroupBase[T]) Pause() {
+	g.main.Pause()
+}
+
+func (g *GroupBase[T]) Resume() {
+	g.main.Resume()
+}
+
+func (g *GroupBase[T]) IsPaused() bool {
+	return g.main.IsPaused()
+}
+
+// Fail marks the main element as failed and closes the group.
+func (g *GroupBase[T]) Fail(err error) error {
+	if err := g.main.Fail(err); err != nil {
+		return err
+	}
+	g.Close()
+	return nil
+}
+
+func (g *GroupBase[T]) GetError() error {
+	return g.main.GetError()
+}
+
+// SetExpected sets the expected number of group members.
+func (g *GroupBase[T]) SetExpected(n int) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.expected = n
+	if e, ok := g.notifier.(specs.ExpectingGroupNotifier); ok && g.aggregation == nil {
+		e.Expect(g.main, n)
+	}
+}
+
 func (g *GroupBase[T]) closeMain() {
+	if g.aggregation != nil {
+		g.updateAggregation(true)
+		g.aggregation.ticker.Close()
+	} else if e, ok := g.notifier.(specs.ExpectingGroupNotifier); ok {
+		e.Finish(g.main)
+	}
 	g.main.Close()
 }
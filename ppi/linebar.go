@@ -4,7 +4,7 @@ import (
 	"github.com/mandelsoft/goutils/general"
 	"github.com/mandelsoft/object"
 	"github.com/mandelsoft/ttycolors"
-	"github.com/mandelsoft/ttycolors/ansi"
+	"github.com/mandelsoft/ttyprogress/blocks"
 	"github.com/mandelsoft/ttyprogress/specs"
 )
 
@@ -81,10 +81,10 @@ func (b *LineBarBaseImpl[T, V]) Line() (string, bool) {
 		return l, done
 	}
 
-	length := ansi.CharLen(l)
+	length := blocks.StringWidth(l)
 	completedWidth := int(float64(length) * (b.Protected().CompletedPercent() / 100.00))
 
-	first, second := ansi.SplitAt(l, completedWidth)
+	first, second := blocks.SplitAt(l, completedWidth)
 	return ttycolors.Sequence(ttycolors.Reverse(first), second).String(), done
 
 }
@@ -2,14 +2,18 @@ package ppi
 
 import (
 	"fmt"
+	"maps"
+	"os"
+	"time"
 
 	"github.com/mandelsoft/goutils/general"
 	"github.com/mandelsoft/goutils/generics"
 	"github.com/mandelsoft/object"
 	"github.com/mandelsoft/ttycolors"
-	"github.com/mandelsoft/ttycolors/ansi"
+	"github.com/mandelsoft/ttyprogress/blocks"
 	"github.com/mandelsoft/ttyprogress/specs"
 	"github.com/mandelsoft/ttyprogress/types"
+	"github.com/mandelsoft/ttyprogress/units"
 )
 
 // ProgressInterface in the public interface of elements
@@ -22,6 +26,7 @@ type ProgressImpl interface {
 	Line() (string, bool)
 	Tick() bool
 	/* abstract protected */ Visualize() (ttycolors.String, bool)
+	/* abstract protected */ ResetProgress()
 	IsAutoClose() bool
 }
 
@@ -51,6 +56,54 @@ func (b *ProgressBase[T]) GetVariable(name string) any {
 	return b.elem.Protected().GetVariable(name)
 }
 
+func (b *ProgressBase[T]) Reset(keepElapsed ...bool) error {
+	b.elem.lock.Lock()
+	defer b.elem.lock.Unlock()
+	return b.elem.Protected().Reset(keepElapsed...)
+}
+
+func (b *ProgressBase[T]) Retries() int {
+	b.elem.lock.RLock()
+	defer b.elem.lock.RUnlock()
+	return b.elem.Protected().Retries()
+}
+
+func (b *ProgressBase[T]) SetBackoff(d time.Duration) {
+	b.elem.lock.Lock()
+	defer b.elem.lock.Unlock()
+	b.elem.Protected().SetBackoff(d)
+}
+
+func (b *ProgressBase[T]) Backoff() time.Duration {
+	b.elem.lock.RLock()
+	defer b.elem.lock.RUnlock()
+	return b.elem.Protected().Backoff()
+}
+
+func (b *ProgressBase[T]) Touch() {
+	b.elem.lock.Lock()
+	defer b.elem.lock.Unlock()
+	b.elem.Protected().Touch()
+}
+
+func (b *ProgressBase[T]) LastUpdate() time.Time {
+	b.elem.lock.RLock()
+	defer b.elem.lock.RUnlock()
+	return b.elem.Protected().LastUpdate()
+}
+
+func (b *ProgressBase[T]) IsStalled() bool {
+	b.elem.lock.RLock()
+	defer b.elem.lock.RUnlock()
+	return b.elem.Protected().IsStalled()
+}
+
+func (b *ProgressBase[T]) TimeStalled() time.Duration {
+	b.elem.lock.RLock()
+	defer b.elem.lock.RUnlock()
+	return b.elem.Protected().TimeStalled()
+}
+
 func (b *ProgressBase[T]) Tick() bool {
 	b.elem.lock.RLock()
 	defer b.elem.lock.RUnlock()
@@ -62,41 +115,70 @@ type ProgressBaseImpl[T ProgressImpl] struct {
 
 	format            ttycolors.Format
 	progressFormat    ttycolors.Format
+	successFormat     ttycolors.Format
+	failureFormat     ttycolors.Format
+	stalledFormat     ttycolors.Format
+	durationFormat    units.DurationFormat
 	appendDecorators  []types.Decorator
 	prependDecorators []types.Decorator
+	namedDecorators   map[string]types.Decorator
+	layout            *specs.Layout
 	variables         map[string]any
 	autoclose         bool
 	minColumn         int
+	alignment         *Alignment
+	alignGeneration   int
 
-	tick    bool
-	tickers []types.Ticker
+	paused    string
+	retries   int
+	retryAt   time.Time
+	tick      bool
+	tickers   []types.Ticker
+	shrinkers []types.Shrinker
+
+	// stallTimeout is the time without progress
+	// after which the element is stalled.
+	stallTimeout time.Duration
+	// lastUpdate is the time of the last progress.
+	lastUpdate time.Time
+	// progressed is the elapsed time of the last progress.
+	progressed time.Duration
 }
 
 var _ ElementImpl = (*ProgressBaseImpl[ProgressImpl])(nil)
 
 func NewProgressBase[T ProgressImpl](self object.Self[T, any], p Container, c specs.ProgressConfiguration, view int, closer func(), tick ...bool) (*ProgressBase[T], *ProgressBaseImpl[T], error) {
 	e := &ProgressBaseImpl[T]{
-		tick:           general.Optional(tick...),
-		variables:      make(map[string]any),
-		autoclose:      c.IsAutoClose(),
-		minColumn:      c.GetMinVisualizationColumn(),
-		format:         c.GetColor(),
-		progressFormat: c.GetProgressColor(),
+		tick:            general.Optional(tick...),
+		variables:       make(map[string]any),
+		namedDecorators: make(map[string]types.Decorator),
+		autoclose:       c.IsAutoClose(),
+		minColumn:       c.GetMinVisualizationColumn(),
+		format:          c.GetColor(),
+		progressFormat:  c.GetProgressColor(),
+		successFormat:   c.GetSuccessColor(),
+		failureFormat:   c.GetFailureColor(),
+		paused:          c.GetPaused(),
+		stallTimeout:    c.GetStallTimeout(),
+		stalledFormat:   c.GetStalledColor(),
+		durationFormat:  c.GetDurationFormat(),
 	}
 
 	for _, def := range c.GetPrependDecorators() {
-		d := def.CreateDecorator(self.Protected())
-		if t, ok := generics.UnwrapUntil[types.Ticker](d); ok {
-			e.tickers = append(e.tickers, t)
-		}
-		e.prependDecorators = append(e.prependDecorators, d)
+		e.prependDecorators = append(e.prependDecorators, e.registerDecorator(def.CreateDecorator(self.Protected())))
 	}
 	for _, def := range c.GetAppendDecorators() {
-		d := def.CreateDecorator(self.Protected())
-		if t, ok := generics.UnwrapUntil[types.Ticker](d); ok {
-			e.tickers = append(e.tickers, t)
+		e.appendDecorators = append(e.appendDecorators, e.registerDecorator(def.CreateDecorator(self.Protected())))
+	}
+	for n, def := range c.GetNamedDecorators() {
+		e.namedDecorators[n] = e.registerDecorator(def.CreateDecorator(self.Protected()))
+	}
+	if c.GetLayout() != "" {
+		l, err := specs.ParseLayout(c.GetLayout())
+		if err != nil {
+			return nil, nil, err
 		}
-		e.appendDecorators = append(e.appendDecorators, d)
+		e.layout = l
 	}
 	if len(e.tickers) > 0 {
 		e.tick = true
@@ -107,9 +189,24 @@ func NewProgressBase[T ProgressImpl](self object.Self[T, any], p Container, c sp
 	}
 	e.ElemBaseImpl = s
 	e.tick = general.OptionalDefaulted(c.GetTick(), tick...)
+	if a, ok := p.(Aligned); ok && a.GetAlignment() != nil {
+		e.alignment = a.GetAlignment()
+	}
 	return &ProgressBase[T]{b, e}, e, nil
 }
 
+// registerDecorator registers the optional ticker and
+// shrinker interfaces of a decorator.
+func (b *ProgressBaseImpl[T]) registerDecorator(d types.Decorator) types.Decorator {
+	if t, ok := generics.UnwrapUntil[types.Ticker](d); ok {
+		b.tickers = append(b.tickers, t)
+	}
+	if s, ok := generics.UnwrapUntil[types.Shrinker](d); ok {
+		b.shrinkers = append(b.shrinkers, s)
+	}
+	return d
+}
+
 func (b *ProgressBaseImpl[T]) SetProgressColor(fmt ttycolors.FormatProvider) {
 	if fmt == nil {
 		b.progressFormat = nil
@@ -128,12 +225,97 @@ func (b *ProgressBaseImpl[T]) GetVariable(name string) any {
 	return b.variables[name]
 }
 
+// Reset resets the progress of a not yet closed element
+// to start a new attempt. Elements already closed, also
+// by Fail, cannot be reset anymore.
+func (b *ProgressBaseImpl[T]) Reset(keepElapsed ...bool) error {
+	if b.closed {
+		return os.ErrClosed
+	}
+	b.retries++
+	b.retryAt = time.Time{}
+	b.reset(general.Optional(keepElapsed...))
+	b.Protected().ResetProgress()
+	b.Touch()
+	b.Protected().Flush()
+	return nil
+}
+
+// ResetProgress resets the element specific progress state.
+// It is called by Reset and may be overridden by
+// derived elements.
+func (b *ProgressBaseImpl[T]) ResetProgress() {
+}
+
+func (b *ProgressBaseImpl[T]) Retries() int {
+	return b.retries
+}
+
+func (b *ProgressBaseImpl[T]) SetBackoff(d time.Duration) {
+	b.retryAt = time.Now().Add(d)
+	b.Protected().Flush()
+}
+
+func (b *ProgressBaseImpl[T]) Backoff() time.Duration {
+	if b.retryAt.IsZero() {
+		return 0
+	}
+	return max(time.Until(b.retryAt), 0)
+}
+
+// Touch records a progress of the element. It should be called
+// by derived elements whenever their progress is updated.
+func (b *ProgressBaseImpl[T]) Touch() {
+	stalled := b.IsStalled()
+	b.lastUpdate = time.Now()
+	b.progressed = b.TimeElapsed()
+	if stalled {
+		b.Protected().Flush()
+	}
+}
+
+// LastUpdate provides the time of the last progress.
+// Before any progress, it is the start time.
+func (b *ProgressBaseImpl[T]) LastUpdate() time.Time {
+	if b.lastUpdate.IsZero() {
+		return b.timeStarted
+	}
+	return b.lastUpdate
+}
+
+// TimeStalled provides the time since the last progress
+// of a stalled element, or 0 if the element is not stalled.
+// Paused time is not counted.
+func (b *ProgressBaseImpl[T]) TimeStalled() time.Duration {
+	if b.stallTimeout <= 0 || !b.IsStarted() || b.closed || b.IsPaused() {
+		return 0
+	}
+	d := b.TimeElapsed() - b.progressed
+	if d < b.stallTimeout {
+		return 0
+	}
+	return d
+}
+
+func (b *ProgressBaseImpl[T]) IsStalled() bool {
+	return b.TimeStalled() > 0
+}
+
+// GetDurationFormat provides the default format
+// for decorators showing durations.
+func (b *ProgressBaseImpl[T]) GetDurationFormat() units.DurationFormat {
+	return b.durationFormat
+}
+
 func (b *ProgressBaseImpl[T]) IsAutoClose() bool {
 	return b.autoclose
 }
 
 func (b *ProgressBaseImpl[T]) Tick() bool {
-	if b.tick && !b.closed && b.IsStarted() {
+	if b.IsOutdatedAlignment() {
+		return b.Protected().Update()
+	}
+	if b.tick && !b.closed && b.IsStarted() && !b.IsPaused() {
 		upd := false
 		for _, t := range b.tickers {
 			upd = t.Tick() || upd
@@ -143,7 +325,20 @@ func (b *ProgressBaseImpl[T]) Tick() bool {
 	return false
 }
 
+// IsOutdatedAlignment reports whether the column widths of
+// the sibling elements changed since the last rendering.
+func (b *ProgressBaseImpl[T]) IsOutdatedAlignment() bool {
+	return b.alignment != nil && b.alignment.Generation() != b.alignGeneration
+}
+
 func (b *ProgressBaseImpl[T]) Line() (string, bool) {
+	if b.layout != nil {
+		return b.layoutLine()
+	}
+	if b.alignment != nil {
+		return b.alignedLine()
+	}
+
 	seq := make([]any, 0, 30)
 	sep := false
 
@@ -151,7 +346,7 @@ func (b *ProgressBaseImpl[T]) Line() (string, bool) {
 	seq, sep = appendDecorators(seq, sep, b.prependDecorators)
 
 	if b.minColumn > 0 {
-		l := ansi.CharLen(b.block.GetGap() + b.String(seq...).String())
+		l := blocks.StringWidth(b.block.GetGap() + b.String(seq...).String())
 		if l < b.minColumn {
 			seq = append(seq, fmt.Sprintf("%*s", b.minColumn-l, ""))
 		}
@@ -163,11 +358,7 @@ func (b *ProgressBaseImpl[T]) Line() (string, bool) {
 		if sep {
 			seq = append(seq, " ")
 		}
-		if b.progressFormat != nil {
-			seq = append(seq, b.progressFormat.String(data))
-		} else {
-			seq = append(seq, data)
-		}
+		seq = append(seq, b.formatVisualization(data, done))
 		sep = true
 	}
 
@@ -180,6 +371,152 @@ func (b *ProgressBaseImpl[T]) Line() (string, bool) {
 	return b.String(seq...).String(), done
 }
 
+func (b *ProgressBaseImpl[T]) formatVisualization(data ttycolors.String, done bool) any {
+	if b.IsPaused() && b.paused != "" {
+		return ttycolors.Sequence(b.formatState(data, done), " ", b.paused)
+	}
+	return b.formatState(data, done)
+}
+
+func (b *ProgressBaseImpl[T]) formatState(data ttycolors.String, done bool) any {
+	if b.err != nil {
+		if b.failureFormat != nil {
+			return b.failureFormat.String(data)
+		}
+	} else if done && b.successFormat != nil {
+		return b.successFormat.String(data)
+	}
+	if b.stalledFormat != nil && b.IsStalled() {
+		return b.stalledFormat.String(data)
+	}
+	if b.progressFormat != nil {
+		return b.progressFormat.String(data)
+	}
+	return data
+}
+
+// alignedLine renders the progress line with all columns
+// padded to the widths of the sibling elements.
+func (b *ProgressBaseImpl[T]) alignedLine() (string, bool) {
+	var own Columns
+
+	prepend := make([]any, len(b.prependDecorators))
+	own.Prepend = make([]int, len(b.prependDecorators))
+	for i, d := range b.prependDecorators {
+		prepend[i] = d.Decorate()
+		own.Prepend[i] = b.columnWidth(prepend[i])
+	}
+	var bar any
+	data, done := b.Protected().Visualize()
+	if data != nil {
+		bar = b.formatVisualization(data, done)
+		own.Visualization = b.columnWidth(bar)
+	}
+	appnd := make([]any, len(b.appendDecorators))
+	own.Append = make([]int, len(b.appendDecorators))
+	for i, d := range b.appendDecorators {
+		appnd[i] = d.Decorate()
+		own.Append[i] = b.columnWidth(appnd[i])
+	}
+
+	// the gap is part of the first column to align
+	// elements with different nesting levels.
+	gap := blocks.StringWidth(b.block.GetGap())
+	max, gen := b.alignment.Update(b, gap, own)
+	b.alignGeneration = gen
+
+	// arrange the columns according to the slots
+	// used by all sibling elements.
+	columns := make([]any, 0, len(max.Prepend)+1+len(max.Append))
+	widths := make([]int, 0, cap(columns))
+	maxwidths := make([]int, 0, cap(columns))
+	for i, m := range max.Prepend {
+		if i < len(prepend) {
+			columns = append(columns, prepend[i])
+			widths = append(widths, own.Prepend[i])
+		} else {
+			columns = append(columns, nil)
+			widths = append(widths, 0)
+		}
+		maxwidths = append(maxwidths, m)
+	}
+	columns = append(columns, bar)
+	widths = append(widths, own.Visualization)
+	maxwidths = append(maxwidths, max.Visualization)
+	for i, m := range max.Append {
+		if i < len(appnd) {
+			columns = append(columns, appnd[i])
+			widths = append(widths, own.Append[i])
+		} else {
+			columns = append(columns, nil)
+			widths = append(widths, 0)
+		}
+		maxwidths = append(maxwidths, m)
+	}
+	widths[0] += gap
+
+	// omit trailing empty columns
+	last := len(columns) - 1
+	for last > 0 && widths[last] == 0 {
+		last--
+	}
+
+	seq := make([]any, 0, 2*len(columns))
+	for i, c := range columns[:last+1] {
+		if i > 0 {
+			seq = append(seq, " ")
+		}
+		if c != nil {
+			seq = append(seq, c)
+		}
+		if i < last && maxwidths[i] > widths[i] {
+			seq = append(seq, fmt.Sprintf("%*s", maxwidths[i]-widths[i], ""))
+		}
+	}
+
+	if b.format != nil {
+		return b.StringWith(b.format, seq...).String(), done
+	}
+	return b.String(seq...).String(), done
+}
+
+func (b *ProgressBaseImpl[T]) columnWidth(c any) int {
+	return blocks.StringWidth(b.String(c).String())
+}
+
+// layoutLine renders the progress line according to the
+// configured layout.
+func (b *ProgressBaseImpl[T]) layoutLine() (string, bool) {
+	values := map[string]any{}
+
+	prepend, _ := appendDecorators(nil, false, b.prependDecorators)
+	values["Prepend"] = b.String(prepend...)
+	appended, _ := appendDecorators(nil, false, b.appendDecorators)
+	values["Append"] = b.String(appended...)
+
+	values["Vars"] = maps.Clone(b.variables)
+
+	data, done := b.Protected().Visualize()
+	if data != nil {
+		values["Bar"] = b.formatVisualization(data, done)
+	} else {
+		values["Bar"] = ""
+	}
+	for n, d := range b.namedDecorators {
+		if v := d.Decorate(); v != nil {
+			values[n] = v
+		} else {
+			values[n] = ""
+		}
+	}
+
+	line := b.layout.Render(b.Protected(), values)
+	if b.format != nil {
+		return b.StringWith(b.format, line).String(), done
+	}
+	return line, done
+}
+
 func appendDecorators(seq []any, sep bool, decorators []types.Decorator) ([]any, bool) {
 	for _, f := range decorators {
 		v := f.Decorate()
@@ -194,11 +531,44 @@ func appendDecorators(seq []any, sep bool, decorators []types.Decorator) ([]any,
 	return seq, sep
 }
 
-func (b *ProgressBaseImpl[T]) Update() bool {
+// fittedLine renders the progress line. If it exceeds the
+// terminal width, the shrinkable decorators are shortened.
+func (b *ProgressBaseImpl[T]) fittedLine() (string, bool) {
 	line, done := b.Protected().Line()
+	if len(b.shrinkers) == 0 {
+		return line, done
+	}
+	width := b.block.Blocks().TermWidth()
+	if width <= 0 {
+		return line, done
+	}
+	excess := blocks.StringWidth(b.block.GetGap()+line) - width
+	if excess <= 0 {
+		return line, done
+	}
+	for _, s := range b.shrinkers {
+		if excess <= 0 {
+			break
+		}
+		excess -= s.Shrink(excess)
+	}
+	line, done = b.Protected().Line()
+	for _, s := range b.shrinkers {
+		s.Shrink(0)
+	}
+	return line, done
+}
 
-	b.block.Reset()
-	b.block.Write([]byte(line + "\n"))
+func (b *ProgressBaseImpl[T]) Update() bool {
+	line, done := b.fittedLine()
+
+	if b.closed {
+		// a closed element may still be realigned.
+		b.block.Rewrite([]byte(line + "\n"))
+	} else {
+		b.block.Reset()
+		b.block.Write([]byte(line + "\n"))
+	}
 	b.block.Flush()
 	if done {
 		if b.Protected().IsAutoClose() {
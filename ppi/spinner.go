@@ -1,6 +1,8 @@
 package ppi
 
 import (
+	"time"
+
 	"github.com/mandelsoft/object"
 	"github.com/mandelsoft/ttycolors"
 	"github.com/mandelsoft/ttyprogress/specs"
@@ -14,6 +16,7 @@ type SpinnerInterface interface {
 
 type SpinnerImpl interface {
 	ProgressImpl
+	Beat()
 }
 
 type SpinnerBase[P SpinnerImpl] struct {
@@ -21,6 +24,12 @@ type SpinnerBase[P SpinnerImpl] struct {
 	elem *SpinnerBaseImpl[P]
 }
 
+func (b *SpinnerBase[P]) Beat() {
+	b.elem.lock.Lock()
+	defer b.elem.lock.Unlock()
+	b.elem.Protected().Beat()
+}
+
 type SpinnerBaseImpl[P SpinnerImpl] struct {
 	*ProgressBaseImpl[P]
 
@@ -30,9 +39,17 @@ type SpinnerBaseImpl[P SpinnerImpl] struct {
 	// done is the message shown after closed
 	done string
 
+	// failed is the message shown after failed
+	failed string
+
 	speed *specs.Speed
 
 	phases specs.Phases
+
+	// heartbeat changes the phase only after an activity
+	// recorded by beat.
+	heartbeat bool
+	beat      bool
 }
 
 var _ SpinnerInterface = (*SpinnerBaseImpl[SpinnerImpl])(nil)
@@ -41,9 +58,15 @@ func NewSpinnerBase[T SpinnerImpl](self object.Self[T, any], p Container, c spec
 	e := &SpinnerBaseImpl[T]{
 		phases:  c.GetPhases(),
 		done:    c.GetDone(),
+		failed:  c.GetFailed(),
 		pending: c.GetPending(),
 		speed:   specs.NewSpeed(c.GetSpeed()),
 	}
+	var interval time.Duration
+	e.heartbeat, interval = c.GetHeartbeat()
+	if interval > 0 {
+		e.speed.SetInterval(interval)
+	}
 	b, s, err := NewProgressBase[T](self, p, c, view, closer, true)
 	if err != nil {
 		return nil, nil, err
@@ -54,6 +77,9 @@ func NewSpinnerBase[T SpinnerImpl](self object.Self[T, any], p Container, c spec
 
 func (s *SpinnerBaseImpl[T]) Visualize() (ttycolors.String, bool) {
 	if s.Protected().IsClosed() {
+		if s.Protected().GetError() != nil {
+			return specs.String(s.failed), true
+		}
 		return specs.String(s.done), true
 	}
 	if !s.Protected().IsStarted() {
@@ -62,14 +88,35 @@ func (s *SpinnerBaseImpl[T]) Visualize() (ttycolors.String, bool) {
 	return s.phases.Get(), false
 }
 
+// Touch records an activity. In heartbeat mode,
+// it enables the next phase change.
+func (s *SpinnerBaseImpl[T]) Touch() {
+	s.ProgressBaseImpl.Touch()
+	s.beat = true
+}
+
+func (s *SpinnerBaseImpl[T]) Beat() {
+	s.Protected().Touch()
+}
+
 func (s *SpinnerBaseImpl[T]) Tick() bool {
-	if s.Protected().IsClosed() {
+	if s.Protected().IsClosed() || s.Protected().IsPaused() {
+		if s.IsOutdatedAlignment() {
+			return s.Protected().Update()
+		}
 		return false
 	}
-	if !s.speed.Tick() {
+	if s.Protected().IsStalled() {
+		// the phase is frozen, only the decorators are updated.
+		return s.ProgressBaseImpl.Tick()
+	}
+	if (s.heartbeat && !s.beat) || !s.speed.Tick() {
+		if s.IsOutdatedAlignment() {
+			return s.Protected().Update()
+		}
 		return false
-
 	}
+	s.beat = false
 	s.phases.Incr()
 	return s.Protected().Update()
 }
@@ -0,0 +1,97 @@
+package ppi
+
+import (
+	"sync"
+
+	"github.com/mandelsoft/ttyprogress/blocks"
+	"github.com/mandelsoft/ttyprogress/specs"
+)
+
+// treeLock synchronizes the line prefix calculation
+// and the folding for all nested groups.
+var treeLock sync.Mutex
+
+// treeNode is implemented by group containers. It is used
+// by nested groups to register themselves at their parent
+// to propagate the line prefixes for the tree rendering.
+type treeNode interface {
+	addTreeNode(b *blocks.Block, n *GroupState)
+}
+
+// treeState is the tree related part of a GroupState.
+type treeState struct {
+	tree       bool
+	connectors specs.TreeConnectors
+
+	// base is the line prefix inherited from the parent groups.
+	base     string
+	children []*blocks.Block
+	nodes    map[*blocks.Block]*GroupState
+}
+
+func (g *GroupState) addTreeNode(b *blocks.Block, n *GroupState) {
+	treeLock.Lock()
+	defer treeLock.Unlock()
+
+	g.nodes[b] = n
+	g.updateMembers()
+}
+
+func (g *GroupState) addTreeChild(b *blocks.Block) {
+	treeLock.Lock()
+	defer treeLock.Unlock()
+
+	g.children = append(g.children, b)
+	g.updateMembers()
+}
+
+// UpdateMembers recalculates the folding and the
+// line prefixes of the group members.
+func (g *GroupState) UpdateMembers() {
+	treeLock.Lock()
+	defer treeLock.Unlock()
+
+	g.updateMembers()
+}
+
+func (g *GroupState) updateMembers() {
+	if g.policy.IsActive() || g.folded {
+		g.updateCollapse()
+	}
+	g.updateTree()
+}
+
+// updateTree sets the line prefixes of the group members.
+// The last visible member gets the connector for the last
+// member, because finished members might be hidden.
+// The prefixes are propagated to nested groups.
+func (g *GroupState) updateTree() {
+	last := -1
+	for i, b := range g.children {
+		if !b.IsHidden() && !b.IsFolded() {
+			last = i
+		}
+	}
+
+	base := g.base
+	if g.tree {
+		base += g.pgap
+	}
+	for i, b := range g.children {
+		first, followup := base, base
+		if g.tree {
+			if i < last {
+				first += g.connectors.Branch
+				followup += g.connectors.Line
+			} else {
+				first += g.connectors.Last
+				followup += g.connectors.Space
+			}
+		}
+		b.SetLinePrefix(first, followup)
+		if n := g.nodes[b]; n != nil {
+			n.base = followup
+			n.updateTree()
+		}
+	}
+}
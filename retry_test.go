@@ -0,0 +1,87 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+var _ = Describe("Retry Test Environment", func() {
+	var p ttyprogress.Context
+
+	BeforeEach(func() {
+		p = ttyprogress.For(&bytes.Buffer{})
+	})
+
+	AfterEach(func() {
+		p.Close()
+	})
+
+	It("counts the retries", func() {
+		bar, err := ttyprogress.NewBar().SetTotal(10).Add(p)
+		Expect(err).To(Succeed())
+		bar.Set(5)
+		Expect(bar.Retries()).To(Equal(0))
+
+		Expect(bar.Reset()).To(Succeed())
+		Expect(bar.Retries()).To(Equal(1))
+		Expect(bar.Current()).To(Equal(0))
+
+		bar.Set(3)
+		Expect(bar.Reset()).To(Succeed())
+		Expect(bar.Retries()).To(Equal(2))
+		Expect(bar.Current()).To(Equal(0))
+		bar.Close()
+	})
+
+	It("restarts the time measurement", func() {
+		bar, err := ttyprogress.NewBar().Add(p)
+		Expect(err).To(Succeed())
+		bar.Start()
+		time.Sleep(50 * time.Millisecond)
+
+		Expect(bar.Reset(true)).To(Succeed())
+		Expect(bar.TimeElapsed()).To(BeNumerically(">=", 50*time.Millisecond))
+		Expect(bar.Reset()).To(Succeed())
+		Expect(bar.TimeElapsed()).To(BeNumerically("<", 50*time.Millisecond))
+		bar.Close()
+	})
+
+	It("provides the backoff until the next attempt", func() {
+		bar, err := ttyprogress.NewBar().Add(p)
+		Expect(err).To(Succeed())
+		bar.Start()
+		Expect(bar.Backoff()).To(BeZero())
+
+		bar.SetBackoff(time.Minute)
+		Expect(bar.Backoff()).To(BeNumerically("~", time.Minute, time.Second))
+		Expect(bar.Reset()).To(Succeed())
+		Expect(bar.Backoff()).To(BeZero())
+
+		bar.SetBackoff(10 * time.Millisecond)
+		Eventually(bar.Backoff).Should(BeZero())
+		bar.Close()
+	})
+
+	It("cannot reset failed elements", func() {
+		bar, err := ttyprogress.NewBar().Add(p)
+		Expect(err).To(Succeed())
+		bar.Start()
+		Expect(bar.Fail(fmt.Errorf("attempt failed"))).To(Succeed())
+		Expect(bar.Reset()).To(MatchError(os.ErrClosed))
+		Expect(bar.Retries()).To(Equal(0))
+	})
+
+	It("cannot reset closed elements", func() {
+		bar, err := ttyprogress.NewBar().Add(p)
+		Expect(err).To(Succeed())
+		bar.Close()
+		Expect(bar.Reset()).To(MatchError(os.ErrClosed))
+	})
+})
@@ -31,7 +31,7 @@ func (d *ScrollingSpinnerDefinition) Dup() *ScrollingSpinnerDefinition {
 }
 
 func (d *ScrollingSpinnerDefinition) Add(c Container) (Spinner, error) {
-	s, err := newSpinner(c, d)
+	s, err := newSpinner(c, specs.InheritTheme(d, c))
 	if s != nil {
 		s.Flush()
 	}
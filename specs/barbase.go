@@ -1,9 +1,19 @@
 package specs
 
+import (
+	"time"
+)
+
 type CompletedPercent interface {
 	CompletedPercent() float64
 }
 
+// RemainingTime is the optional interface of elements
+// able to estimate their remaining time on their own.
+type RemainingTime interface {
+	TimeRemaining() (time.Duration, bool)
+}
+
 type BarBaseInterface[V any] interface {
 	ProgressInterface
 	CompletedPercent
@@ -12,9 +22,9 @@ type BarBaseInterface[V any] interface {
 
 type BarBaseDefinition[T any] struct {
 	ProgressDefinition[T]
-	width     uint
-	pending   string
-	config    BarConfig
+	width     *uint
+	pending   *string
+	config    *BarConfig
 	autoclose bool
 }
 
@@ -25,9 +35,6 @@ var _ BarBaseSpecification[any] = (*BarBaseDefinition[any])(nil)
 func NewBarBaseDefinition[T any](self Self[T]) BarBaseDefinition[T] {
 	return BarBaseDefinition[T]{
 		ProgressDefinition: NewProgressDefinition(self),
-		config:             BarTypes[BarType],
-		width:              BarWidth,
-		pending:            Pending,
 		autoclose:          true,
 	}
 }
@@ -54,73 +61,100 @@ func (d *BarBaseDefinition[T]) PrependCompleted(offset ...int) T {
 	return d.Self()
 }
 
+// AppendETA appends the estimated remaining time to the progress bar.
+// Elements providing an own estimation (like Steps with expected
+// durations) use it, otherwise it is extrapolated from the elapsed
+// time and the completion percent.
+func (d *BarBaseDefinition[T]) AppendETA(offset ...int) T {
+	d.tick = true
+	return d.AppendFunc(d.DurationFunc(timeRemaining), offset...)
+}
+
+// PrependETA prepends the estimated remaining time to the progress bar.
+func (d *BarBaseDefinition[T]) PrependETA(offset ...int) T {
+	d.tick = true
+	return d.PrependFunc(d.DurationFunc(timeRemaining), offset...)
+}
+
 func (d *BarBaseDefinition[T]) SetWidth(w uint) T {
-	d.width = w
+	d.width = &w
 	return d.Self()
 }
 
 func (d *BarBaseDefinition[T]) GetWidth() uint {
-	return d.width
+	if d.width == nil {
+		return d.effectiveTheme().BarWidth
+	}
+	return *d.width
 }
 
 func (d *BarBaseDefinition[T]) SetPending(m string) T {
-	d.pending = m
+	d.pending = &m
 	return d.Self()
 }
 
 func (d *BarBaseDefinition[T]) GetPending() string {
-	return d.pending
+	if d.pending == nil {
+		return d.effectiveTheme().Pending
+	}
+	return *d.pending
 }
 
 func (d *BarBaseDefinition[T]) SetConfig(c BarConfig) T {
-	d.config = c
+	d.config = &c
 	return d.Self()
 }
 
 func (d *BarBaseDefinition[T]) GetConfig() BarConfig {
-	return d.config
+	if d.config == nil {
+		return d.effectiveTheme().BarConfig
+	}
+	return *d.config
 }
 
 func (d *BarBaseDefinition[T]) SetPredefined(i int) T {
 	if c, ok := BarTypes[i]; ok {
-		d.config = c
+		d.SetConfig(c)
 	}
 	return d.Self()
 }
 
 func (d *BarBaseDefinition[T]) SetBrackets(c Brackets) T {
-	d.config = d.config.SetBrackets(c)
-	return d.Self()
+	return d.SetConfig(d.GetConfig().SetBrackets(c))
 }
 
 func (d *BarBaseDefinition[T]) SetBracketType(i int) T {
-	d.config = d.config.SetBracketType(i)
-	return d.Self()
+	return d.SetConfig(d.GetConfig().SetBracketType(i))
 }
 
 func (d *BarBaseDefinition[T]) SetHead(c rune) T {
-	d.config.Head = c
-	return d.Self()
+	cfg := d.GetConfig()
+	cfg.Head = c
+	return d.SetConfig(cfg)
 }
 
 func (d *BarBaseDefinition[T]) SetEmpty(c rune) T {
-	d.config.Empty = c
-	return d.Self()
+	cfg := d.GetConfig()
+	cfg.Empty = c
+	return d.SetConfig(cfg)
 }
 
 func (d *BarBaseDefinition[T]) SetFill(c rune) T {
-	d.config.Fill = c
-	return d.Self()
+	cfg := d.GetConfig()
+	cfg.Fill = c
+	return d.SetConfig(cfg)
 }
 
 func (d *BarBaseDefinition[T]) SetLeftEnd(c rune) T {
-	d.config.LeftEnd = c
-	return d.Self()
+	cfg := d.GetConfig()
+	cfg.LeftEnd = c
+	return d.SetConfig(cfg)
 }
 
 func (d *BarBaseDefinition[T]) SetRightEnd(c rune) T {
-	d.config.RightEnd = c
-	return d.Self()
+	cfg := d.GetConfig()
+	cfg.RightEnd = c
+	return d.SetConfig(cfg)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -130,6 +164,9 @@ type BarBaseSpecification[T any] interface {
 	AppendCompleted(offset ...int) T
 	PrependCompleted(offset ...int) T
 
+	AppendETA(offset ...int) T
+	PrependETA(offset ...int) T
+
 	SetPending(m string) T
 	SetWidth(w uint) T
 	SetConfig(c BarConfig) T
@@ -158,3 +195,26 @@ func TransferBarBaseConfig[D BarBaseSpecification[T], T any](d D, c BarBaseConfi
 	d.SetPending(c.GetPending())
 	return TransferProgressConfig(d, c)
 }
+
+////////////////////////////////////////////////////////////////////////////////
+
+func timeRemaining(e ElementState) (time.Duration, bool) {
+	if !e.IsStarted() {
+		return 0, false
+	}
+	return estimateRemaining(e)
+}
+
+func estimateRemaining(e ElementState) (time.Duration, bool) {
+	if r, ok := e.(RemainingTime); ok {
+		if t, ok := r.TimeRemaining(); ok {
+			return t, true
+		}
+	}
+	if c, ok := e.(CompletedPercent); ok {
+		if p := c.CompletedPercent(); p > 0 {
+			return time.Duration(float64(e.TimeElapsed()) * (100 - p) / p), true
+		}
+	}
+	return 0, false
+}
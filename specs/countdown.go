@@ -0,0 +1,136 @@
+package specs
+
+import (
+	"time"
+
+	"github.com/mandelsoft/goutils/general"
+	"github.com/mandelsoft/ttycolors"
+)
+
+type CountdownInterface interface {
+	BarBaseInterface[time.Duration]
+	RemainingTime
+
+	Total() time.Duration
+
+	// Deadline provides the point in time the countdown expires.
+	// It is zero, if the countdown is not yet started.
+	Deadline() time.Time
+	// IsExpired reports whether the deadline is reached.
+	IsExpired() bool
+
+	IsFinished() bool
+	// Set sets the remaining time.
+	Set(d time.Duration) bool
+}
+
+type CountdownDefinition[T any] struct {
+	BarBaseDefinition[T]
+
+	total         time.Duration
+	deadline      time.Time
+	warning       time.Duration
+	warningFormat ttycolors.Format
+	failOnExpiry  bool
+}
+
+// NewCountdownDefinition can be used to create a nested definition
+// for a derived countdown definition.
+func NewCountdownDefinition[T any](self Self[T], total time.Duration) CountdownDefinition[T] {
+	d := CountdownDefinition[T]{total: total}
+	d.BarBaseDefinition = NewBarBaseDefinition(self)
+	return d
+}
+
+func (d *CountdownDefinition[T]) Dup(s Self[T]) CountdownDefinition[T] {
+	dup := *d
+	dup.BarBaseDefinition = d.BarBaseDefinition.Dup(s)
+	return dup
+}
+
+// PrependRemaining prepends the remaining time to the bar.
+func (d *CountdownDefinition[T]) PrependRemaining(offset ...int) T {
+	return d.PrependFunc(d.DurationFunc(remainingTime), offset...)
+}
+
+// AppendRemaining appends the remaining time to the bar.
+func (d *CountdownDefinition[T]) AppendRemaining(offset ...int) T {
+	return d.AppendFunc(d.DurationFunc(remainingTime), offset...)
+}
+
+// SetTotal sets the time between the start and
+// the expiry of the countdown.
+func (d *CountdownDefinition[T]) SetTotal(v time.Duration) T {
+	d.total = v
+	return d.Self()
+}
+
+func (d *CountdownDefinition[T]) GetTotal() time.Duration {
+	return d.total
+}
+
+// SetDeadline sets a fixed point in time the countdown expires.
+// The bar drains from the start of the element to the deadline.
+// In contrast to a total duration, the deadline is not
+// shifted by pausing the element.
+func (d *CountdownDefinition[T]) SetDeadline(t time.Time) T {
+	d.deadline = t
+	return d.Self()
+}
+
+func (d *CountdownDefinition[T]) GetDeadline() time.Time {
+	return d.deadline
+}
+
+// SetWarning sets the format used for the progress visualization
+// as soon as the remaining time falls below the given threshold.
+func (d *CountdownDefinition[T]) SetWarning(threshold time.Duration, f ...ttycolors.FormatProvider) T {
+	d.warning = threshold
+	d.warningFormat = ttycolors.New(f...)
+	return d.Self()
+}
+
+func (d *CountdownDefinition[T]) GetWarning() (time.Duration, ttycolors.Format) {
+	return d.warning, d.warningFormat
+}
+
+// SetFailOnExpiry sets whether the element fails with
+// ErrExpired, if the deadline is reached before it is closed.
+// Otherwise, it is closed on expiry if auto close is enabled.
+func (d *CountdownDefinition[T]) SetFailOnExpiry(b ...bool) T {
+	d.failOnExpiry = general.OptionalDefaultedBool(true, b...)
+	return d.Self()
+}
+
+func (d *CountdownDefinition[T]) IsFailOnExpiry() bool {
+	return d.failOnExpiry
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func remainingTime(e ElementState) (time.Duration, bool) {
+	if r, ok := e.(RemainingTime); ok && e.IsStarted() {
+		return r.TimeRemaining()
+	}
+	return 0, false
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type CountdownSpecification[T any] interface {
+	BarBaseSpecification[T]
+	PrependRemaining(offset ...int) T
+	AppendRemaining(offset ...int) T
+	SetTotal(v time.Duration) T
+	SetDeadline(t time.Time) T
+	SetWarning(threshold time.Duration, f ...ttycolors.FormatProvider) T
+	SetFailOnExpiry(b ...bool) T
+}
+
+type CountdownConfiguration interface {
+	BarBaseConfiguration
+	GetTotal() time.Duration
+	GetDeadline() time.Time
+	GetWarning() (time.Duration, ttycolors.Format)
+	IsFailOnExpiry() bool
+}
@@ -0,0 +1,146 @@
+package specs
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/mandelsoft/ttyprogress/types"
+)
+
+type DAGStepsInterface interface {
+	ProgressInterface
+
+	// Step provides the element for the step with the given name.
+	Step(name string) ElementInterface
+	// Running provides the names of the actually running steps.
+	Running() []string
+}
+
+// DAGStep describes a step of a DAGSteps element.
+// It is started, after all its predecessors are finished.
+type DAGStep struct {
+	name  string
+	def   types.ElementDefinition[ElementInterface]
+	after []string
+	// labeled guards the decoration of the shared
+	// definition with the step name.
+	labeled *sync.Once
+}
+
+func NewDAGStep[T ElementInterface](name string, def types.ElementDefinition[T], after ...string) DAGStep {
+	return DAGStep{
+		name:    name,
+		def:     types.GenericDefinition(def),
+		after:   slices.Clone(after),
+		labeled: &sync.Once{},
+	}
+}
+
+func (n *DAGStep) Name() string {
+	return n.name
+}
+
+func (n *DAGStep) Definition() types.ElementDefinition[ElementInterface] {
+	return n.def
+}
+
+// LabeledDefinition provides the definition of the step decorated
+// with a leading label. The definition is decorated only once, even
+// if the step is used for multiple elements.
+func (n *DAGStep) LabeledDefinition(label string) types.ElementDefinition[ElementInterface] {
+	if n.labeled == nil {
+		n.labeled = &sync.Once{}
+	}
+	n.labeled.Do(func() { PrependFunc(n.def, Message(label), 0) })
+	return n.def
+}
+
+// After provides the names of the predecessors of the step.
+func (n *DAGStep) After() []string {
+	return slices.Clone(n.after)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type DAGStepsDefinition[T any] struct {
+	BarBaseDefinition[T]
+	GroupBaseDefinition[T]
+
+	steps   []DAGStep
+	skipped *string
+}
+
+// NewDAGStepsDefinition can be used to create a nested definition
+// for a derived DAG steps definition.
+func NewDAGStepsDefinition[T any](self Self[T], steps []DAGStep) DAGStepsDefinition[T] {
+	d := DAGStepsDefinition[T]{steps: slices.Clone(steps)}
+	d.BarBaseDefinition = NewBarBaseDefinition(self)
+	d.GroupBaseDefinition = NewGroupBaseDefinition(self)
+	return d
+}
+
+func (d *DAGStepsDefinition[T]) Dup(s Self[T]) DAGStepsDefinition[T] {
+	dup := *d
+	dup.BarBaseDefinition = d.BarBaseDefinition.Dup(s)
+	dup.GroupBaseDefinition = d.GroupBaseDefinition.Dup(s)
+	return dup
+}
+
+// SetTheme sets the Theme used for the main progress indicator
+// and the step elements.
+func (d *DAGStepsDefinition[T]) SetTheme(t *Theme) T {
+	d.BarBaseDefinition.SetTheme(t)
+	return d.GroupBaseDefinition.SetTheme(t)
+}
+
+func (d *DAGStepsDefinition[T]) GetTheme() *Theme {
+	return d.GroupBaseDefinition.GetTheme()
+}
+
+func (d *DAGStepsDefinition[T]) inheritTheme(t *Theme) {
+	d.BarBaseDefinition.inheritTheme(t)
+	d.GroupBaseDefinition.inheritTheme(t)
+}
+
+func (d *DAGStepsDefinition[T]) SetSteps(steps []DAGStep) T {
+	d.steps = slices.Clone(steps)
+	return d.Self()
+}
+
+func (d *DAGStepsDefinition[T]) GetSteps() []DAGStep {
+	return slices.Clone(d.steps)
+}
+
+// SetSkipped sets the message shown for steps skipped
+// because of failed predecessors.
+func (d *DAGStepsDefinition[T]) SetSkipped(m string) T {
+	d.skipped = &m
+	return d.Self()
+}
+
+func (d *DAGStepsDefinition[T]) GetSkipped() string {
+	if d.skipped == nil {
+		return d.BarBaseDefinition.effectiveTheme().Skipped
+	}
+	return *d.skipped
+}
+
+func (d *DAGStepsDefinition[T]) GetTotal() int {
+	return len(d.steps)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type DAGStepsSpecification[T any] interface {
+	BarBaseSpecification[T]
+	GroupBaseSpecification[T]
+	SetSteps(steps []DAGStep) T
+	SetSkipped(m string) T
+}
+
+type DAGStepsConfiguration interface {
+	GroupBaseConfiguration
+	BarBaseConfiguration
+	GetSteps() []DAGStep
+	GetSkipped() string
+}
@@ -1,8 +1,14 @@
 package specs
 
-var (
+// Built-in defaults used to initialize a Theme.
+// To modify the defaults for a set of elements, a Theme
+// can be attached to a Context, group or definition.
+const (
 	Done             = "done"
+	Failed           = "failed"
+	Skipped          = "skipped"
 	Pending          = "pending"
+	Paused           = "paused"
 	BarWidth         = uint(10)
 	BarType          = 0
 	SpinnerSpeed     = 2
@@ -10,4 +16,5 @@ var (
 	TextView         = 3
 	GroupGap         = "- "
 	GroupFollowUpGap = "  "
+	Folded           = "✓ %d done"
 )
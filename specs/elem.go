@@ -3,7 +3,9 @@ package specs
 import (
 	"github.com/mandelsoft/goutils/optionutils"
 	"github.com/mandelsoft/ttycolors"
+	"github.com/mandelsoft/ttyprogress/blocks"
 	"github.com/mandelsoft/ttyprogress/types"
+	"github.com/mandelsoft/ttyprogress/units"
 )
 
 // ElementInterface is the common interface of all
@@ -17,6 +19,8 @@ type ElementDefinition[T any] struct {
 	final       string
 	hideOnClose bool
 	hide        bool
+	theme       *Theme
+	overflow    blocks.Overflow
 }
 
 var (
@@ -65,6 +69,40 @@ func (e *ElementDefinition[T]) GetFinal() string {
 	return e.final
 }
 
+func (e *ElementDefinition[T]) SetOverflow(o blocks.Overflow) T {
+	e.overflow = o
+	return e.self.Self()
+}
+
+func (e *ElementDefinition[T]) GetOverflow() blocks.Overflow {
+	return e.overflow
+}
+
+func (e *ElementDefinition[T]) SetTheme(t *Theme) T {
+	e.theme = t
+	return e.self.Self()
+}
+
+func (e *ElementDefinition[T]) GetTheme() *Theme {
+	return e.theme
+}
+
+func (e *ElementDefinition[T]) inheritTheme(t *Theme) {
+	e.theme = t
+}
+
+// effectiveTheme provides the Theme used to
+// default unset attributes.
+func (e *ElementDefinition[T]) effectiveTheme() *Theme {
+	return EffectiveTheme(e.theme)
+}
+
+// GetDurationFormat provides the default format for decorators
+// and line prefixes showing a duration.
+func (e *ElementDefinition[T]) GetDurationFormat() units.DurationFormat {
+	return e.effectiveTheme().DurationFormat
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 // TitleLineProvider is the optional interface to provide a title line configuration
@@ -104,6 +142,31 @@ type ViewFormatProvider interface {
 	GetViewFormat() ttycolors.Format
 }
 
+type (
+	Line           = blocks.Line
+	LinePrefixFunc = blocks.LinePrefixFunc
+	LineFormatFunc = blocks.LineFormatFunc
+	LineFilterFunc = blocks.LineFilterFunc
+)
+
+// LinePrefixProvider is the optional interface to provide
+// a prefix function for the lines of the element content.
+type LinePrefixProvider interface {
+	GetLinePrefix() LinePrefixFunc
+}
+
+// LineFormatProvider is the optional interface to provide
+// a format function for the lines of the element content.
+type LineFormatProvider interface {
+	GetLineFormat() LineFormatFunc
+}
+
+// ViewFilterProvider is the optional interface to provide
+// a filter for the lines shown in the view window.
+type ViewFilterProvider interface {
+	GetViewFilter() LineFilterFunc
+}
+
 type ElementSpecification[T any] interface {
 	// SetFinal sets a text message shown instead of the
 	// text window after the action has been finished.
@@ -114,6 +177,14 @@ type ElementSpecification[T any] interface {
 
 	// Hide will request to initially hide the element.
 	Hide(...bool) T
+
+	// SetOverflow sets the handling of lines exceeding the
+	// terminal width. By default, the policy of the Context is used.
+	SetOverflow(blocks.Overflow) T
+
+	// SetTheme sets the Theme used for unset attributes.
+	// By default, the Theme of the container is used.
+	SetTheme(*Theme) T
 }
 
 type ElementConfiguration interface {
@@ -122,6 +193,8 @@ type ElementConfiguration interface {
 	GetFinal() string
 	GetHideOnClose() bool
 	GetHide() bool
+	GetOverflow() blocks.Overflow
+	ThemeProvider
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -130,5 +203,7 @@ func TransferElementConfig[D ElementSpecification[T], T any](d D, c ElementConfi
 	d.HideOnClose(c.GetHideOnClose())
 	d.Hide(c.GetHide())
 	d.SetFinal(c.GetFinal())
+	d.SetOverflow(c.GetOverflow())
+	d.SetTheme(c.GetTheme())
 	return d
 }
@@ -2,8 +2,6 @@ package specs
 
 import (
 	"time"
-
-	"github.com/mandelsoft/goutils/stringutils"
 )
 
 type EstimatedInterface interface {
@@ -20,6 +18,8 @@ type EstimatedDefinition[T any] struct {
 	BarBaseDefinition[T]
 
 	total time.Duration
+
+	historyConfig
 }
 
 // NewEstimatedDefinition can be used to create a nested definition
@@ -38,18 +38,12 @@ func (d *EstimatedDefinition[T]) Dup(s Self[T]) EstimatedDefinition[T] {
 
 // PrependEstimated prepends the time elapsed to the beginning of the bar
 func (d *EstimatedDefinition[T]) PrependEstimated(offset ...int) T {
-	d.PrependFunc(func(e ElementState) any {
-		return estimatedTime(e)
-	}, offset...)
-	return d.Self()
+	return d.PrependFunc(d.DurationFunc(estimatedTime), offset...)
 }
 
 // AppendEstimated appends the time elapsed to the beginning of the bar
 func (d *EstimatedDefinition[T]) AppendEstimated(offset ...int) T {
-	d.AppendFunc(func(e ElementState) any {
-		return estimatedTime(e)
-	}, offset...)
-	return d.Self()
+	return d.AppendFunc(d.DurationFunc(estimatedTime), offset...)
 }
 
 func (d *EstimatedDefinition[T]) SetTotal(v time.Duration) T {
@@ -57,19 +51,27 @@ func (d *EstimatedDefinition[T]) SetTotal(v time.Duration) T {
 	return d.Self()
 }
 
+// UseHistory seeds the expected total duration with the durations
+// recorded for the given name in previous runs, and records
+// the actual durations.
+func (d *EstimatedDefinition[T]) UseHistory(h History, name string) T {
+	d.history = h
+	d.name = name
+	return d.Self()
+}
+
 func (d *EstimatedDefinition[T]) GetTotal() time.Duration {
 	return d.total
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 
-func estimatedTime(e ElementState) string {
-	s := ""
-	if e.IsStarted() {
-		p := e.(EstimatedInterface)
-		s = PrettyTime(p.Total() - p.TimeElapsed())
+func estimatedTime(e ElementState) (time.Duration, bool) {
+	if !e.IsStarted() {
+		return 0, false
 	}
-	return stringutils.PadLeft(s, 5, ' ')
+	p := e.(EstimatedInterface)
+	return p.Total() - p.TimeElapsed(), true
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -77,9 +79,11 @@ func estimatedTime(e ElementState) string {
 type EstimatedSpecification[T any] interface {
 	BarBaseSpecification[T]
 	SetTotal(v time.Duration) T
+	UseHistory(h History, name string) T
 }
 
 type EstimatedConfiguration interface {
 	BarBaseConfiguration
 	GetTotal() time.Duration
+	HistoryProvider
 }
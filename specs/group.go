@@ -1,6 +1,7 @@
 package specs
 
 import (
+	"github.com/mandelsoft/goutils/general"
 	"github.com/mandelsoft/ttyprogress/types"
 )
 
@@ -25,6 +26,37 @@ var _ GroupNotifier = (*VoidGroupNotifier)(nil)
 func (d *VoidGroupNotifier) Add(e ProgressInterface, o any)  {}
 func (d *VoidGroupNotifier) Done(e ProgressInterface, o any) {}
 
+// ProgressAggregator is the optional interface of a GroupNotifier
+// able to show the aggregated completion percent of the group members.
+// If final is set, all members are finished.
+type ProgressAggregator interface {
+	Aggregate(e ProgressInterface, percent float64, final bool)
+}
+
+// ExpectingGroupNotifier is the optional interface of a GroupNotifier
+// supporting an expected number of group members. Finish is called
+// after all members are finished to adjust the main progress indicator
+// to the actual number of members.
+type ExpectingGroupNotifier interface {
+	GroupNotifier
+	Expect(e ProgressInterface, n int)
+	Finish(e ProgressInterface)
+}
+
+// MemberWeight provides the weight of a group member
+// used to aggregate the completion percent of a group.
+type MemberWeight func(e ElementInterface) float64
+
+// WeightByTotal weights group members providing
+// a total (like bars) by their total. Other members
+// get weight 1.
+func WeightByTotal(e ElementInterface) float64 {
+	if t, ok := e.(interface{ Total() int }); ok {
+		return float64(t.Total())
+	}
+	return 1
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type GroupInterface interface {
@@ -35,6 +67,10 @@ type GroupInterface interface {
 type GroupDefinition[T any, E ProgressInterface] struct {
 	GroupBaseDefinition[T]
 	main GroupProgressElementDefinition[E]
+
+	aggregate bool
+	weight    MemberWeight
+	expected  int
 }
 
 func NewGroupDefinition[T any, E ProgressInterface](s Self[T], main GroupProgressElementDefinition[E]) *GroupDefinition[T, E] {
@@ -54,10 +90,46 @@ func (d *GroupDefinition[T, E]) GetProgress() GroupProgressElementDefinition[E]
 	return d.main
 }
 
+// AggregateProgress lets the main progress indicator show the average
+// completion percent of the group members instead of the number of
+// finished members. Members without a completion percent count with 0%
+// until they are closed. An optional weight function can be given to
+// weight the members (for example WeightByTotal).
+// This requires a main progress indicator supporting the aggregation
+// (like a Bar).
+func (d *GroupDefinition[T, E]) AggregateProgress(weight ...MemberWeight) T {
+	d.aggregate = true
+	d.weight = general.Optional(weight...)
+	return d.self.Self()
+}
+
+// SetExpected declares the expected number of group members.
+// It is used as total for the main progress indicator as long as
+// fewer members are added. This avoids a completed group indicator
+// for groups with lazily added members.
+func (d *GroupDefinition[T, E]) SetExpected(n int) T {
+	d.expected = n
+	return d.self.Self()
+}
+
+func (d *GroupDefinition[T, E]) GetExpected() int {
+	return d.expected
+}
+
+func (d *GroupDefinition[T, E]) IsAggregateProgress() bool {
+	return d.aggregate
+}
+
+func (d *GroupDefinition[T, E]) GetMemberWeight() MemberWeight {
+	return d.weight
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type GroupSpecification[T any] interface {
 	GroupBaseSpecification[T]
+	AggregateProgress(weight ...MemberWeight) T
+	SetExpected(n int) T
 }
 
 type GroupConfiguration[E ProgressInterface] interface {
@@ -65,4 +137,8 @@ type GroupConfiguration[E ProgressInterface] interface {
 
 	// GetProgress provides the main group progress indicator.
 	GetProgress() GroupProgressElementDefinition[E]
+
+	IsAggregateProgress() bool
+	GetMemberWeight() MemberWeight
+	GetExpected() int
 }
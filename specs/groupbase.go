@@ -10,11 +10,33 @@ type GroupBaseInterface interface {
 	Hide(b ...bool)
 }
 
+// CollapsePolicy describes the folding of group members.
+type CollapsePolicy struct {
+	// Active is the maximum number of shown unfinished members.
+	// The most recently active members are shown.
+	// 0 shows all unfinished members.
+	Active int
+	// FoldFinished folds finished members into a summary line.
+	FoldFinished bool
+	// ExpandFailed keeps failed members visible.
+	ExpandFailed bool
+}
+
+// IsActive reports whether the policy folds any members.
+func (p CollapsePolicy) IsActive() bool {
+	return p.Active > 0 || p.FoldFinished
+}
+
 type GroupBaseDefinition[T any] struct {
 	self        Self[T]
-	gap         string
-	followup    string
+	gap         *string
+	followup    *string
 	hideOnClose bool
+	align       bool
+	tree        bool
+	connectors  *TreeConnectors
+	collapse    CollapsePolicy
+	theme       *Theme
 }
 
 var _ GroupBaseSpecification[any] = (*GroupBaseDefinition[any])(nil)
@@ -24,9 +46,7 @@ var _ GroupBaseConfiguration = (*GroupBaseDefinition[any])(nil)
 // for a derived group definition.
 func NewGroupBaseDefinition[T any](self Self[T]) GroupBaseDefinition[T] {
 	return GroupBaseDefinition[T]{
-		self:     self,
-		gap:      GroupGap,
-		followup: GroupFollowUpGap,
+		self: self,
 	}
 }
 
@@ -45,22 +65,105 @@ func (d *GroupBaseDefinition[T]) IsHideOnClose() bool {
 	return d.hideOnClose
 }
 
+// AlignColumns enables the column alignment for the elements
+// of the group. The decorators and the visualization of all
+// progress elements are padded to the maximum width used
+// by the elements of the group.
+func (d *GroupBaseDefinition[T]) AlignColumns(b ...bool) T {
+	d.align = optionutils.BoolOption(b...)
+	return d.self.Self()
+}
+
+func (d *GroupBaseDefinition[T]) IsAlignColumns() bool {
+	return d.align
+}
+
+// Collapse limits the shown unfinished members of the group to
+// the given number of most recently active ones. 0 shows all members.
+func (d *GroupBaseDefinition[T]) Collapse(n int) T {
+	d.collapse.Active = n
+	return d.self.Self()
+}
+
+// FoldFinished folds finished members of the group into a
+// summary line showing their number.
+func (d *GroupBaseDefinition[T]) FoldFinished(b ...bool) T {
+	d.collapse.FoldFinished = optionutils.BoolOption(b...)
+	return d.self.Self()
+}
+
+// ExpandFailed keeps failed members visible, even if they
+// would be folded according to the collapse policy.
+func (d *GroupBaseDefinition[T]) ExpandFailed(b ...bool) T {
+	d.collapse.ExpandFailed = optionutils.BoolOption(b...)
+	return d.self.Self()
+}
+
+func (d *GroupBaseDefinition[T]) GetCollapsePolicy() CollapsePolicy {
+	return d.collapse
+}
+
+// Tree enables the tree mode for the group. Instead of
+// gaps the group members are prefixed with tree connectors.
+func (d *GroupBaseDefinition[T]) Tree(b ...bool) T {
+	d.tree = optionutils.BoolOption(b...)
+	return d.self.Self()
+}
+
+func (d *GroupBaseDefinition[T]) IsTree() bool {
+	return d.tree
+}
+
+// SetTreeConnectors sets the connectors used in tree mode.
+func (d *GroupBaseDefinition[T]) SetTreeConnectors(c TreeConnectors) T {
+	d.connectors = &c
+	return d.self.Self()
+}
+
+func (d *GroupBaseDefinition[T]) GetTreeConnectors() TreeConnectors {
+	if d.connectors == nil {
+		return EffectiveTheme(d.theme).TreeConnectors
+	}
+	return *d.connectors
+}
+
 func (d *GroupBaseDefinition[T]) SetGap(gap string) T {
-	d.gap = gap
+	d.gap = &gap
 	return d.self.Self()
 }
 
 func (d *GroupBaseDefinition[T]) GetGap() string {
-	return d.gap
+	if d.gap == nil {
+		return EffectiveTheme(d.theme).GroupGap
+	}
+	return *d.gap
 }
 
 func (d *GroupBaseDefinition[T]) SetFollowUpGap(gap string) T {
-	d.followup = gap
+	d.followup = &gap
 	return d.self.Self()
 }
 
 func (d *GroupBaseDefinition[T]) GetFollowUpGap() string {
-	return d.followup
+	if d.followup == nil {
+		return EffectiveTheme(d.theme).GroupFollowUpGap
+	}
+	return *d.followup
+}
+
+// SetTheme sets the Theme used for the group and
+// all elements added to the group.
+func (d *GroupBaseDefinition[T]) SetTheme(t *Theme) T {
+	d.theme = t
+	return d.self.Self()
+}
+
+func (d *GroupBaseDefinition[T]) GetTheme() *Theme {
+	return d.theme
+}
+
+func (d *GroupBaseDefinition[T]) inheritTheme(t *Theme) {
+	d.theme = t
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -69,10 +172,22 @@ type GroupBaseSpecification[T any] interface {
 	SetGap(string) T
 	SetFollowUpGap(string) T
 	HideOnClose(b ...bool) T
+	AlignColumns(b ...bool) T
+	Tree(b ...bool) T
+	Collapse(n int) T
+	FoldFinished(b ...bool) T
+	ExpandFailed(b ...bool) T
+	SetTreeConnectors(c TreeConnectors) T
+	SetTheme(t *Theme) T
 }
 
 type GroupBaseConfiguration interface {
+	ThemeProvider
 	GetFollowUpGap() string
 	GetGap() string
 	IsHideOnClose() bool
+	IsAlignColumns() bool
+	IsTree() bool
+	GetCollapsePolicy() CollapsePolicy
+	GetTreeConnectors() TreeConnectors
 }
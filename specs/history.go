@@ -0,0 +1,34 @@
+package specs
+
+import (
+	"time"
+)
+
+// History is a store for the durations of recurring elements.
+// It is used to seed the expected durations of elements with
+// the durations of previous runs (see package history).
+type History interface {
+	// Estimate provides the expected duration for a key.
+	Estimate(key string) (time.Duration, bool)
+	// Record adds an actual duration for a key.
+	// It is called while the element is locked and
+	// should not block, for example, on file I/O.
+	Record(key string, d time.Duration)
+}
+
+// HistoryProvider is the optional interface of configurations
+// using a History.
+type HistoryProvider interface {
+	GetHistory() (History, string)
+}
+
+// historyConfig is the History related part of a definition.
+type historyConfig struct {
+	history History
+	name    string
+}
+
+// GetHistory provides the History and the name used as key.
+func (h *historyConfig) GetHistory() (History, string) {
+	return h.history, h.name
+}
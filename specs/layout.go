@@ -0,0 +1,150 @@
+package specs
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/mandelsoft/ttyprogress/blocks"
+)
+
+// Layout is a parsed progress line layout based on
+// a text/template.
+// The data passed to the template is a map containing
+//   - Bar: the visualization of the element (for example the bar or the spinner phase)
+//   - Prepend: the output of all prepended decorators
+//   - Append: the output of all appended decorators
+//   - Element: the element state
+//   - Vars: the variables of the element
+//   - Percent: the completion percentage, if provided by the element
+//   - Elapsed: the elapsed time
+//   - all named decorators
+//
+// Decorators added with the Append and Prepend methods are only
+// available by the fields Prepend and Append. Other texts, like
+// messages, must be added as named decorators. Fields without a
+// value are rendered empty.
+//
+// For width and alignment the functions left, right and center
+// can be used, for example {{ .Message | left 20 }} for a
+// named decorator Message.
+type Layout struct {
+	tmpl *template.Template
+	// fields are the top-level fields used by the template.
+	fields []string
+}
+
+var layoutFuncs = template.FuncMap{
+	"left":   alignLeft,
+	"right":  alignRight,
+	"center": alignCenter,
+}
+
+// ParseLayout parses a layout specification.
+func ParseLayout(s string) (*Layout, error) {
+	t, err := template.New("layout").Funcs(layoutFuncs).Option("missingkey=zero").Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	l := &Layout{tmpl: t}
+	for _, n := range t.Templates() {
+		l.fields = collectFields(l.fields, n.Tree.Root)
+	}
+	return l, nil
+}
+
+// collectFields collects the top-level fields used by a template.
+func collectFields(fields []string, n parse.Node) []string {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n != nil {
+			for _, c := range n.Nodes {
+				fields = collectFields(fields, c)
+			}
+		}
+	case *parse.ActionNode:
+		fields = collectFields(fields, n.Pipe)
+	case *parse.PipeNode:
+		if n != nil {
+			for _, c := range n.Cmds {
+				fields = collectFields(fields, c)
+			}
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			fields = collectFields(fields, a)
+		}
+	case *parse.FieldNode:
+		fields = append(fields, n.Ident[0])
+	case *parse.IfNode:
+		fields = collectBranchFields(fields, &n.BranchNode)
+	case *parse.RangeNode:
+		fields = collectBranchFields(fields, &n.BranchNode)
+	case *parse.WithNode:
+		fields = collectBranchFields(fields, &n.BranchNode)
+	case *parse.TemplateNode:
+		fields = collectFields(fields, n.Pipe)
+	}
+	return fields
+}
+
+func collectBranchFields(fields []string, n *parse.BranchNode) []string {
+	fields = collectFields(fields, n.Pipe)
+	fields = collectFields(fields, n.List)
+	return collectFields(fields, n.ElseList)
+}
+
+// Render renders the layout for the given element state.
+func (l *Layout) Render(e ElementState, data map[string]any) string {
+	data["Element"] = e
+	if _, ok := data["Elapsed"]; !ok {
+		t, ok := timeElapsed(e)
+		data["Elapsed"] = FormatDuration(e, nil, t, ok)
+	}
+	if _, ok := data["Percent"]; !ok {
+		if p, ok := e.(CompletedPercent); ok {
+			data["Percent"] = PercentString(p.CompletedPercent())
+		}
+	}
+	for _, f := range l.fields {
+		if _, ok := data[f]; !ok {
+			data[f] = ""
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := l.tmpl.Execute(&buf, data); err != nil {
+		return fmt.Sprintf("layout error: %s", err)
+	}
+	return buf.String()
+}
+
+func layoutString(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func fill(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat(" ", n)
+}
+
+func alignLeft(w int, v any) string {
+	return blocks.PadRight(layoutString(v), w)
+}
+
+func alignRight(w int, v any) string {
+	return blocks.PadLeft(layoutString(v), w)
+}
+
+func alignCenter(w int, v any) string {
+	s := layoutString(v)
+	n := w - blocks.StringWidth(s)
+	return fill(n/2) + s + fill(n-n/2)
+}
@@ -2,6 +2,7 @@ package specs
 
 import (
 	"slices"
+	"time"
 
 	"github.com/mandelsoft/goutils/general"
 	"github.com/mandelsoft/ttyprogress/types"
@@ -11,11 +12,23 @@ type NestedStepsInterface interface {
 	ProgressInterface
 	Incr() (ElementInterface, error)
 	Current() ElementInterface
+
+	// AddStep appends an additional step.
+	AddStep(step NestedStep) error
+	// Skip marks the current step as skipped and
+	// continues with the next step.
+	Skip() (ElementInterface, error)
+	// GoTo continues with the step with the given name.
+	// Steps jumped over are marked as skipped. Steps
+	// preceding the current step cannot be executed again.
+	GoTo(name string) (ElementInterface, error)
 }
 
 type NestedStep struct {
-	name string
-	def  types.ElementDefinition[ElementInterface]
+	name     string
+	def      types.ElementDefinition[ElementInterface]
+	weight   float64
+	duration time.Duration
 }
 
 func NewNestedStep[T ElementInterface](name string, def types.ElementDefinition[T]) NestedStep {
@@ -33,6 +46,28 @@ func (n *NestedStep) Definition() types.ElementDefinition[ElementInterface] {
 	return n.def
 }
 
+// WithWeight provides the step with a weight used to calculate
+// the completion percent of the main progress indicator.
+func (n NestedStep) WithWeight(w float64) NestedStep {
+	n.weight = w
+	return n
+}
+
+func (n *NestedStep) Weight() float64 {
+	return n.weight
+}
+
+// WithDuration provides the step with an expected duration.
+// If no weight is given, it is used as weight, also.
+func (n NestedStep) WithDuration(d time.Duration) NestedStep {
+	n.duration = d
+	return n
+}
+
+func (n *NestedStep) Duration() time.Duration {
+	return n.duration
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type NestedStepsDefinition[T any] struct {
@@ -41,6 +76,9 @@ type NestedStepsDefinition[T any] struct {
 
 	steps         []NestedStep
 	showStepTitle bool
+	skipped       *string
+
+	historyConfig
 }
 
 // NewNestedStepsDefinition can be used to create a nested definition
@@ -56,9 +94,26 @@ func NewNestedStepsDefinition[T any](self Self[T], steps []NestedStep) NestedSte
 func (d *NestedStepsDefinition[T]) Dup(s Self[T]) NestedStepsDefinition[T] {
 	dup := *d
 	dup.BarBaseDefinition = d.BarBaseDefinition.Dup(s)
+	dup.GroupBaseDefinition = d.GroupBaseDefinition.Dup(s)
 	return dup
 }
 
+// SetTheme sets the Theme used for the main progress indicator
+// and the step elements.
+func (d *NestedStepsDefinition[T]) SetTheme(t *Theme) T {
+	d.BarBaseDefinition.SetTheme(t)
+	return d.GroupBaseDefinition.SetTheme(t)
+}
+
+func (d *NestedStepsDefinition[T]) GetTheme() *Theme {
+	return d.GroupBaseDefinition.GetTheme()
+}
+
+func (d *NestedStepsDefinition[T]) inheritTheme(t *Theme) {
+	d.BarBaseDefinition.inheritTheme(t)
+	d.GroupBaseDefinition.inheritTheme(t)
+}
+
 func (d *NestedStepsDefinition[T]) SetSteps(steps []NestedStep) T {
 	d.steps = slices.Clone(steps)
 	return d.Self()
@@ -68,6 +123,8 @@ func (d *NestedStepsDefinition[T]) GetSteps() []NestedStep {
 	return slices.Clone(d.steps)
 }
 
+// ShowStepTitle sets whether the main progress indicator
+// provides the name of the current step.
 func (d *NestedStepsDefinition[T]) ShowStepTitle(b ...bool) T {
 	d.showStepTitle = general.OptionalDefaultedBool(true, b...)
 	return d.Self()
@@ -77,6 +134,28 @@ func (d *NestedStepsDefinition[T]) IsShowStepTitle() bool {
 	return d.showStepTitle
 }
 
+// SetSkipped sets the message shown for skipped steps.
+func (d *NestedStepsDefinition[T]) SetSkipped(m string) T {
+	d.skipped = &m
+	return d.Self()
+}
+
+func (d *NestedStepsDefinition[T]) GetSkipped() string {
+	if d.skipped == nil {
+		return d.BarBaseDefinition.effectiveTheme().Skipped
+	}
+	return *d.skipped
+}
+
+// UseHistory seeds the expected step durations with the durations
+// recorded for the given name in previous runs, and records
+// the actual durations.
+func (d *NestedStepsDefinition[T]) UseHistory(h History, name string) T {
+	d.history = h
+	d.name = name
+	return d.Self()
+}
+
 func (d *NestedStepsDefinition[T]) GetTotal() int {
 	return len(d.steps)
 }
@@ -88,6 +167,8 @@ type NestedStepsSpecification[T any] interface {
 	GroupBaseSpecification[T]
 	SetSteps(steps []NestedStep) T
 	ShowStepTitle(b ...bool) T
+	SetSkipped(m string) T
+	UseHistory(h History, name string) T
 }
 
 type NestedStepsConfiguration interface {
@@ -95,4 +176,6 @@ type NestedStepsConfiguration interface {
 	BarBaseConfiguration
 	GetSteps() []NestedStep
 	IsShowStepTitle() bool
+	GetSkipped() string
+	HistoryProvider
 }
@@ -1,12 +1,16 @@
 package specs
 
 import (
+	"fmt"
+	"maps"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/mandelsoft/goutils/optionutils"
-	"github.com/mandelsoft/goutils/stringutils"
 	"github.com/mandelsoft/ttycolors"
 	"github.com/mandelsoft/ttyprogress/types"
+	"github.com/mandelsoft/ttyprogress/units"
 )
 
 type ProgressInterface = types.ProgressElement
@@ -18,12 +22,20 @@ type ProgressDefinition[T any] struct {
 
 	format              ttycolors.Format
 	progressFormat      ttycolors.Format
+	successFormat       ttycolors.Format
+	failureFormat       ttycolors.Format
 	nextdecoratorFormat ttycolors.Format
+	nextDurationFormat  units.DurationFormat
 	appendDefs          []DecoratorDefinition
 	prependDefs         []DecoratorDefinition
+	namedDefs           map[string]DecoratorDefinition
+	layout              string
 	autoclose           bool
 	minColumn           int
 	tick                bool
+	paused              *string
+	stallTimeout        *time.Duration
+	stalledFormat       ttycolors.Format
 }
 
 var (
@@ -42,12 +54,13 @@ func (d *ProgressDefinition[T]) Dup(s Self[T]) ProgressDefinition[T] {
 	dup.ElementDefinition = d.ElementDefinition.Dup(s)
 	dup.appendDefs = slices.Clone(dup.appendDefs)
 	dup.prependDefs = slices.Clone(dup.prependDefs)
+	dup.namedDefs = maps.Clone(dup.namedDefs)
 	return dup
 }
 
 // GetTick returns whether a tick is required.
 func (d *ProgressDefinition[T]) GetTick() bool {
-	return d.tick
+	return d.tick || d.GetStallTimeout() > 0
 }
 
 // setTick returns whether a tick is required.
@@ -64,12 +77,61 @@ func (d *ProgressDefinition[T]) IsAutoClose() bool {
 	return d.autoclose
 }
 
+// SetPaused sets the marker shown after the progress
+// visualization while the element is paused.
+func (d *ProgressDefinition[T]) SetPaused(m string) T {
+	d.paused = &m
+	return d.Self()
+}
+
+func (d *ProgressDefinition[T]) GetPaused() string {
+	if d.paused == nil {
+		return d.effectiveTheme().Paused
+	}
+	return *d.paused
+}
+
 // SetDecoratorFormat sets the output format for the next decorator.
 func (d *ProgressDefinition[T]) SetDecoratorFormat(f ...ttycolors.FormatProvider) T {
 	d.nextdecoratorFormat = ttycolors.New(f...)
 	return d.Self()
 }
 
+// SetDecoratorDurationFormat sets the format used by the next
+// decorator showing a duration, like AppendElapsed or AppendETA.
+// By default, the DurationFormat of the theme is used.
+func (d *ProgressDefinition[T]) SetDecoratorDurationFormat(f units.DurationFormat) T {
+	d.nextDurationFormat = f
+	return d.Self()
+}
+
+// DurationFunc provides a decorator function showing the duration
+// provided by the given function. It uses the duration format set
+// for the next decorator, or the default format of the element.
+func (d *ProgressDefinition[T]) DurationFunc(f func(e ElementState) (time.Duration, bool)) DecoratorFunc {
+	df := d.nextDurationFormat
+	d.nextDurationFormat = nil
+	return func(e ElementState) any {
+		t, ok := f(e)
+		return FormatDuration(e, df, t, ok)
+	}
+}
+
+// labeledDurationFunc provides a decorator function showing the
+// duration provided by the given function with a leading label,
+// like DurationFunc. If no duration is available, nothing is shown.
+func (d *ProgressDefinition[T]) labeledDurationFunc(label string, f func(e ElementState) (time.Duration, bool)) DecoratorFunc {
+	df := d.nextDurationFormat
+	d.nextDurationFormat = nil
+	return func(e ElementState) any {
+		t, ok := f(e)
+		if !ok {
+			return ""
+		}
+		return label + strings.TrimLeft(FormatDuration(e, df, t, ok), " ")
+	}
+}
+
 func (d *ProgressDefinition[T]) SetMinVisualizationColumn(c int) T {
 	d.minColumn = c
 	return d.Self()
@@ -96,9 +158,69 @@ func (d *ProgressDefinition[T]) SetProgressColor(f ...ttycolors.FormatProvider)
 }
 
 func (d *ProgressDefinition[T]) GetProgressColor() ttycolors.Format {
+	if d.progressFormat == nil {
+		return d.effectiveTheme().ProgressFormat
+	}
 	return d.progressFormat
 }
 
+// SetSuccessColor sets the output format for the progress indicator
+// of finished elements.
+func (d *ProgressDefinition[T]) SetSuccessColor(f ...ttycolors.FormatProvider) T {
+	d.successFormat = ttycolors.New(f...)
+	return d.Self()
+}
+
+func (d *ProgressDefinition[T]) GetSuccessColor() ttycolors.Format {
+	if d.successFormat == nil {
+		return d.effectiveTheme().SuccessFormat
+	}
+	return d.successFormat
+}
+
+// SetFailureColor sets the output format for the progress indicator
+// of failed elements.
+func (d *ProgressDefinition[T]) SetFailureColor(f ...ttycolors.FormatProvider) T {
+	d.failureFormat = ttycolors.New(f...)
+	return d.Self()
+}
+
+func (d *ProgressDefinition[T]) GetFailureColor() ttycolors.Format {
+	if d.failureFormat == nil {
+		return d.effectiveTheme().FailureFormat
+	}
+	return d.failureFormat
+}
+
+// SetStallTimeout sets the time without progress after which
+// the element is considered to be stalled. 0 disables
+// the stall detection.
+func (d *ProgressDefinition[T]) SetStallTimeout(t time.Duration) T {
+	d.stallTimeout = &t
+	return d.Self()
+}
+
+func (d *ProgressDefinition[T]) GetStallTimeout() time.Duration {
+	if d.stallTimeout == nil {
+		return d.effectiveTheme().StallTimeout
+	}
+	return *d.stallTimeout
+}
+
+// SetStalledColor sets the output format for the progress indicator
+// of stalled elements.
+func (d *ProgressDefinition[T]) SetStalledColor(f ...ttycolors.FormatProvider) T {
+	d.stalledFormat = ttycolors.New(f...)
+	return d.Self()
+}
+
+func (d *ProgressDefinition[T]) GetStalledColor() ttycolors.Format {
+	if d.stalledFormat == nil {
+		return d.effectiveTheme().StalledFormat
+	}
+	return d.stalledFormat
+}
+
 func format(fmt *ttycolors.Format, def DecoratorDefinition) DecoratorDefinition {
 	if *fmt == nil {
 		return def
@@ -167,16 +289,88 @@ func (d *ProgressDefinition[T]) GetPrependDecorators() []DecoratorDefinition {
 	return slices.Clone(d.prependDefs)
 }
 
+// SetNamedDecorator adds a decorator, which can be used by name
+// in a layout.
+func (d *ProgressDefinition[T]) SetNamedDecorator(name string, def DecoratorDefinition) T {
+	if d.namedDefs == nil {
+		d.namedDefs = map[string]DecoratorDefinition{}
+	}
+	d.namedDefs[name] = format(&d.nextdecoratorFormat, def)
+	return d.Self()
+}
+
+// SetNamedFunc adds a decorator function, which can be used by name
+// in a layout.
+func (d *ProgressDefinition[T]) SetNamedFunc(name string, f DecoratorFunc) T {
+	return d.SetNamedDecorator(name, f)
+}
+
+func (d *ProgressDefinition[T]) GetNamedDecorators() map[string]DecoratorDefinition {
+	return maps.Clone(d.namedDefs)
+}
+
+// SetLayout sets a layout for the progress line based on
+// a text/template (see Layout).
+// If set, it replaces the default composition of the
+// decorators and the visualization.
+func (d *ProgressDefinition[T]) SetLayout(l string) T {
+	d.layout = l
+	d.tick = d.tick || l != ""
+	return d.Self()
+}
+
+func (d *ProgressDefinition[T]) GetLayout() string {
+	return d.layout
+}
+
 // AppendElapsed appends the time elapsed to the progress indicator
 func (d *ProgressDefinition[T]) AppendElapsed(offset ...int) T {
 	d.tick = true
-	return d.AppendFunc(timeElapsed, offset...)
+	return d.AppendFunc(d.DurationFunc(timeElapsed), offset...)
 }
 
 // PrependElapsed prepends the time elapsed to the beginning of the indicator
 func (d *ProgressDefinition[T]) PrependElapsed(offset ...int) T {
 	d.tick = true
-	return d.PrependFunc(timeElapsed, offset...)
+	return d.PrependFunc(d.DurationFunc(timeElapsed), offset...)
+}
+
+// AppendRetries appends the number of retries to the progress indicator.
+func (d *ProgressDefinition[T]) AppendRetries(offset ...int) T {
+	return d.AppendFunc(retries, offset...)
+}
+
+// PrependRetries prepends the number of retries to the progress indicator.
+func (d *ProgressDefinition[T]) PrependRetries(offset ...int) T {
+	return d.PrependFunc(retries, offset...)
+}
+
+// AppendBackoff appends the time to wait for the next attempt
+// to the progress indicator.
+func (d *ProgressDefinition[T]) AppendBackoff(offset ...int) T {
+	d.tick = true
+	return d.AppendFunc(d.labeledDurationFunc("retrying in ", backoff), offset...)
+}
+
+// PrependBackoff prepends the time to wait for the next attempt
+// to the progress indicator.
+func (d *ProgressDefinition[T]) PrependBackoff(offset ...int) T {
+	d.tick = true
+	return d.PrependFunc(d.labeledDurationFunc("retrying in ", backoff), offset...)
+}
+
+// AppendStalled appends the time since the last progress
+// of a stalled element to the progress indicator.
+func (d *ProgressDefinition[T]) AppendStalled(offset ...int) T {
+	d.tick = true
+	return d.AppendFunc(d.labeledDurationFunc("stalled ", stalled), offset...)
+}
+
+// PrependStalled prepends the time since the last progress
+// of a stalled element to the progress indicator.
+func (d *ProgressDefinition[T]) PrependStalled(offset ...int) T {
+	d.tick = true
+	return d.PrependFunc(d.labeledDurationFunc("stalled ", stalled), offset...)
 }
 
 // AppendMessage appends text to the progress indicator
@@ -199,12 +393,35 @@ func (d *ProgressDefinition[T]) PrependVariable(m string, offset ...int) T {
 	return d.PrependFunc(Variable(m), offset...)
 }
 
-func timeElapsed(e ElementState) any {
-	var s string
-	if e.IsStarted() {
-		s = PrettyTime(e.TimeElapsed())
+func timeElapsed(e ElementState) (time.Duration, bool) {
+	return e.TimeElapsed(), e.IsStarted()
+}
+
+func retries(e ElementState) any {
+	if r, ok := e.(interface{ Retries() int }); ok && r.Retries() > 0 {
+		return fmt.Sprintf("retry %d", r.Retries())
+	}
+	return ""
+}
+
+// backoff provides the remaining backoff time
+// rounded up to full seconds.
+func backoff(e ElementState) (time.Duration, bool) {
+	if b, ok := e.(interface{ Backoff() time.Duration }); ok {
+		if d := b.Backoff(); d > 0 {
+			return (d + time.Second - 1).Truncate(time.Second), true
+		}
+	}
+	return 0, false
+}
+
+func stalled(e ElementState) (time.Duration, bool) {
+	if s, ok := e.(interface{ TimeStalled() time.Duration }); ok {
+		if d := s.TimeStalled(); d > 0 {
+			return d, true
+		}
 	}
-	return stringutils.PadLeft(s, 5, ' ')
+	return 0, false
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -223,9 +440,32 @@ type ProgressSpecification[T any] interface {
 	// SetProgressColor set the color used for the progress visualization.
 	SetProgressColor(col ...ttycolors.FormatProvider) T
 
+	// SetSuccessColor set the color used for the progress visualization
+	// of finished elements.
+	SetSuccessColor(col ...ttycolors.FormatProvider) T
+
+	// SetFailureColor set the color used for the progress visualization
+	// of failed elements.
+	SetFailureColor(col ...ttycolors.FormatProvider) T
+
 	// SetDecoratorFormat set the output format for the next decorator.
 	SetDecoratorFormat(col ...ttycolors.FormatProvider) T
 
+	// SetDecoratorDurationFormat sets the format for the next
+	// decorator showing a duration.
+	SetDecoratorDurationFormat(f units.DurationFormat) T
+
+	// SetPaused sets the marker shown while the element is paused.
+	SetPaused(m string) T
+
+	// SetStallTimeout sets the time without progress after which
+	// the element is considered to be stalled.
+	SetStallTimeout(t time.Duration) T
+
+	// SetStalledColor sets the color used for the progress
+	// visualization of stalled elements.
+	SetStalledColor(col ...ttycolors.FormatProvider) T
+
 	// SetMinVisualizationColumn sets the minimal column for the visualization,
 	SetMinVisualizationColumn(int) T
 
@@ -253,6 +493,19 @@ type ProgressSpecification[T any] interface {
 	// specify the index in the list of functions.
 	PrependDecorator(f DecoratorDefinition, offset ...int) T
 
+	// SetNamedDecorator adds a decorator, which can be used by name
+	// in a layout.
+	SetNamedDecorator(name string, def DecoratorDefinition) T
+
+	// SetNamedFunc adds a decorator function, which can be used by name
+	// in a layout.
+	SetNamedFunc(name string, f DecoratorFunc) T
+
+	// SetLayout sets a text/template based layout for the progress line.
+	// It replaces the default composition of prepended decorators,
+	// visualization and appended decorators (see Layout).
+	SetLayout(l string) T
+
 	// AppendElapsed appends the elapsed time of the action
 	// or the duration of the action if the element is already closed.
 	AppendElapsed(offset ...int) T
@@ -261,6 +514,26 @@ type ProgressSpecification[T any] interface {
 	// or the duration of the action if the element is already closed.
 	PrependElapsed(offset ...int) T
 
+	// AppendRetries appends the number of retries.
+	AppendRetries(offset ...int) T
+
+	// PrependRetries prepends the number of retries.
+	PrependRetries(offset ...int) T
+
+	// AppendBackoff appends the time to wait for the next attempt.
+	AppendBackoff(offset ...int) T
+
+	// PrependBackoff prepends the time to wait for the next attempt.
+	PrependBackoff(offset ...int) T
+
+	// AppendStalled appends the time since the last progress
+	// of stalled elements.
+	AppendStalled(offset ...int) T
+
+	// PrependStalled prepends the time since the last progress
+	// of stalled elements.
+	PrependStalled(offset ...int) T
+
 	// AppendMessage appends text to the progress indicator.
 	AppendMessage(m string, offset ...int) T
 
@@ -278,9 +551,17 @@ type ProgressConfiguration interface {
 
 	GetColor() ttycolors.Format
 	GetProgressColor() ttycolors.Format
+	GetSuccessColor() ttycolors.Format
+	GetFailureColor() ttycolors.Format
 	GetPrependDecorators() []DecoratorDefinition
 	GetAppendDecorators() []DecoratorDefinition
+	GetNamedDecorators() map[string]DecoratorDefinition
+	GetLayout() string
 	GetMinVisualizationColumn() int
+	GetPaused() string
+	GetStallTimeout() time.Duration
+	GetStalledColor() ttycolors.Format
+	GetDurationFormat() units.DurationFormat
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -292,9 +573,14 @@ func TransferProgressConfig[D ProgressSpecification[T], T any](d D, c ProgressCo
 	for _, e := range c.GetAppendDecorators() {
 		d.AppendDecorator(e)
 	}
+	for n, e := range c.GetNamedDecorators() {
+		d.SetNamedDecorator(n, e)
+	}
+	d.SetLayout(c.GetLayout())
 	d.SetAutoClose(c.IsAutoClose())
 	d.SetColor(c.GetColor())
 	d.setTick(c.GetTick())
+	d.SetStallTimeout(c.GetStallTimeout())
 	return TransferElementConfig(d, c)
 }
 
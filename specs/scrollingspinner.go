@@ -1,15 +1,24 @@
 package specs
 
+import (
+	"time"
+
+	"github.com/mandelsoft/goutils/general"
+)
+
 type ScrollingSpinnerInterface interface {
-	ProgressInterface
+	SpinnerInterface
 }
 
 type ScrollingSpinnerDefinition[T any] struct {
 	ProgressDefinition[T]
 
-	done    string
+	done    *string
+	failed  *string
 	phases  Phases
 	pending string
+
+	heartbeatConfig
 }
 
 var (
@@ -22,7 +31,6 @@ var (
 func NewScrollingSpinnerDefinition[T any](self Self[T], text string, length int) ScrollingSpinnerDefinition[T] {
 	d := ScrollingSpinnerDefinition[T]{
 		ProgressDefinition: NewProgressDefinition(self),
-		done:               Done,
 	}
 	t := text + " "
 	if len(t) <= length {
@@ -45,12 +53,28 @@ func (d *ScrollingSpinnerDefinition[T]) Dup(s Self[T]) ScrollingSpinnerDefinitio
 }
 
 func (d *ScrollingSpinnerDefinition[T]) SetDone(m string) T {
-	d.done = m
+	d.done = &m
 	return d.Self()
 }
 
 func (d *ScrollingSpinnerDefinition[T]) GetDone() string {
-	return d.done
+	if d.done == nil {
+		return d.effectiveTheme().Done
+	}
+	return *d.done
+}
+
+// SetFailed sets the message shown for failed elements.
+func (d *ScrollingSpinnerDefinition[T]) SetFailed(m string) T {
+	d.failed = &m
+	return d.Self()
+}
+
+func (d *ScrollingSpinnerDefinition[T]) GetFailed() string {
+	if d.failed == nil {
+		return d.effectiveTheme().Failed
+	}
+	return *d.failed
 }
 
 func (d *ScrollingSpinnerDefinition[T]) SetPending(m string) T {
@@ -66,6 +90,22 @@ func (d *ScrollingSpinnerDefinition[T]) GetSpeed() int {
 	return 3
 }
 
+// GetStallTimeout provides the stall timeout. The stall timeout of
+// the theme is only used in heartbeat mode.
+func (d *ScrollingSpinnerDefinition[T]) GetStallTimeout() time.Duration {
+	return d.heartbeatConfig.stallTimeout(d.ProgressDefinition.stallTimeout, d.ProgressDefinition.GetStallTimeout())
+}
+
+// SetHeartbeat enables the heartbeat mode. The text is
+// scrolled only if an activity is recorded with Beat or Touch.
+// Optionally, the minimal interval between two scroll steps
+// can be given.
+func (d *ScrollingSpinnerDefinition[T]) SetHeartbeat(interval ...time.Duration) T {
+	d.heartbeat = true
+	d.interval = general.Optional(interval...)
+	return d.Self()
+}
+
 func (d *ScrollingSpinnerDefinition[T]) GetPhases() Phases {
 	return d.phases
 }
@@ -75,6 +115,8 @@ func (d *ScrollingSpinnerDefinition[T]) GetPhases() Phases {
 type ScrollingSpinnerSpecification[T any] interface {
 	ProgressSpecification[T]
 	SetDone(string) T
+	SetFailed(string) T
+	SetHeartbeat(interval ...time.Duration) T
 }
 
 type ScrollingSpinnerConfiguration = SpinnerConfiguration
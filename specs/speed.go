@@ -16,6 +16,11 @@ func (t *Speed) SetSpeed(n int) {
 	t.interval = Tick * 5 * time.Duration(n)
 }
 
+// SetInterval sets the minimal interval between two ticks.
+func (t *Speed) SetInterval(d time.Duration) {
+	t.interval = d
+}
+
 func (t *Speed) Tick1() bool {
 	t.passed += Tick
 
@@ -1,20 +1,56 @@
 package specs
 
 import (
+	"time"
+
+	"github.com/mandelsoft/goutils/general"
 	"github.com/mandelsoft/ttycolors"
 )
 
 type SpinnerInterface interface {
 	ProgressInterface
+
+	// Beat records an activity like Touch. Spinners in
+	// heartbeat mode advance their phase only on activities.
+	Beat()
+}
+
+// heartbeatConfig is the heartbeat related part of a definition.
+type heartbeatConfig struct {
+	heartbeat bool
+	interval  time.Duration
+}
+
+// GetHeartbeat provides whether the heartbeat mode is enabled
+// and the minimal interval between two phase changes.
+func (h *heartbeatConfig) GetHeartbeat() (bool, time.Duration) {
+	return h.heartbeat, h.interval
+}
+
+// stallTimeout provides the stall timeout for spinners. Spinners
+// record progress only in heartbeat mode, therefore the stall timeout
+// of the theme is used only in this mode. An explicitly configured
+// timeout is always used, activities must then be recorded with Touch.
+func (h *heartbeatConfig) stallTimeout(d *time.Duration, theme time.Duration) time.Duration {
+	if d != nil {
+		return *d
+	}
+	if h.heartbeat {
+		return theme
+	}
+	return 0
 }
 
 type SpinnerDefinition[T any] struct {
 	ProgressDefinition[T]
 
-	done    string
-	speed   int
+	done    *string
+	failed  *string
+	speed   *int
 	phases  Phases
 	pending string
+
+	heartbeatConfig
 }
 
 var (
@@ -25,14 +61,9 @@ var (
 // NewSpinnerDefinition can be used to create a nested definition
 // for a derived spinner definition.
 func NewSpinnerDefinition[T any](self Self[T]) SpinnerDefinition[T] {
-	d := SpinnerDefinition[T]{
+	return SpinnerDefinition[T]{
 		ProgressDefinition: NewProgressDefinition(self),
-		speed:              SpinnerSpeed,
-		done:               Done,
 	}
-	d.SetPredefined(SpinnerType)
-	return d
-
 }
 
 func (d *SpinnerDefinition[T]) Dup(s Self[T]) SpinnerDefinition[T] {
@@ -49,12 +80,28 @@ func (d *SpinnerDefinition[T]) SetPredefined(i int) T {
 }
 
 func (d *SpinnerDefinition[T]) SetDone(m string) T {
-	d.done = m
+	d.done = &m
 	return d.Self()
 }
 
 func (d *SpinnerDefinition[T]) GetDone() string {
-	return d.done
+	if d.done == nil {
+		return d.effectiveTheme().Done
+	}
+	return *d.done
+}
+
+// SetFailed sets the message shown for failed elements.
+func (d *SpinnerDefinition[T]) SetFailed(m string) T {
+	d.failed = &m
+	return d.Self()
+}
+
+func (d *SpinnerDefinition[T]) GetFailed() string {
+	if d.failed == nil {
+		return d.effectiveTheme().Failed
+	}
+	return *d.failed
 }
 
 func (d *SpinnerDefinition[T]) SetPending(m string) T {
@@ -67,12 +114,32 @@ func (d *SpinnerDefinition[T]) GetPending() string {
 }
 
 func (d *SpinnerDefinition[T]) SetSpeed(v int) T {
-	d.speed = v
+	d.speed = &v
 	return d.Self()
 }
 
 func (d *SpinnerDefinition[T]) GetSpeed() int {
-	return d.speed
+	if d.speed == nil {
+		return d.effectiveTheme().SpinnerSpeed
+	}
+	return *d.speed
+}
+
+// GetStallTimeout provides the stall timeout. The stall timeout of
+// the theme is only used in heartbeat mode.
+func (d *SpinnerDefinition[T]) GetStallTimeout() time.Duration {
+	return d.heartbeatConfig.stallTimeout(d.ProgressDefinition.stallTimeout, d.ProgressDefinition.GetStallTimeout())
+}
+
+// SetHeartbeat enables the heartbeat mode. Instead of changing
+// the phase on a regular basis, the phase is changed only if an
+// activity is recorded with Beat or Touch since the last change.
+// Optionally, the minimal interval between two phase changes
+// can be given. By default, it is derived from the speed.
+func (d *SpinnerDefinition[T]) SetHeartbeat(interval ...time.Duration) T {
+	d.heartbeat = true
+	d.interval = general.Optional(interval...)
+	return d.Self()
 }
 
 func (d *SpinnerDefinition[T]) SetSimplePhases(p ...string) T {
@@ -91,6 +158,9 @@ func (d *SpinnerDefinition[T]) SetFormattedPhases(p ...ttycolors.String) T {
 }
 
 func (d *SpinnerDefinition[T]) GetPhases() Phases {
+	if d.phases == nil {
+		return NewStaticPhases(d.effectiveTheme().SpinnerPhases...)
+	}
 	return d.phases
 }
 
@@ -100,16 +170,20 @@ type SpinnerSpecification[T any] interface {
 	ProgressSpecification[T]
 	SetPredefined(i int) T
 	SetSpeed(v int) T
+	SetHeartbeat(interval ...time.Duration) T
 	SetSimplePhases(p ...string) T
 	SetFormattedPhases(p ...ttycolors.String) T
 	SetPhases(p Phases) T
 	SetDone(string) T
+	SetFailed(string) T
 }
 
 type SpinnerConfiguration interface {
 	ProgressConfiguration
 	GetPending() string
 	GetDone() string
+	GetFailed() string
 	GetSpeed() int
+	GetHeartbeat() (bool, time.Duration)
 	GetPhases() Phases
 }
@@ -8,12 +8,20 @@ import (
 type StepsInterface interface {
 	BarInterface
 	GetCurrentStep() string
+	SetSteps(steps ...string)
+	// TimeRemaining provides the expected remaining time,
+	// if expected step durations are configured.
+	TimeRemaining() (time.Duration, bool)
 }
 
 type StepsDefinition[T any] struct {
 	BarBaseDefinition[T]
 
-	steps []string
+	steps     []string
+	weights   []float64
+	durations []time.Duration
+
+	historyConfig
 }
 
 // NewStepsDefinition can be used to create a nested definition
@@ -54,6 +62,40 @@ func (d *StepsDefinition[T]) GetSteps() []string {
 	return slices.Clone(d.steps)
 }
 
+// SetWeights sets the weights of the steps used to calculate
+// the completion percent. Steps without weight count with
+// weight 1, even if durations are set.
+func (d *StepsDefinition[T]) SetWeights(w ...float64) T {
+	d.weights = slices.Clone(w)
+	return d.Self()
+}
+
+func (d *StepsDefinition[T]) GetWeights() []float64 {
+	return slices.Clone(d.weights)
+}
+
+// SetDurations sets the expected durations of the steps.
+// If no weights are given, the durations are used as weights,
+// steps without duration count as one second.
+// They are used to estimate the remaining time (see AppendETA).
+func (d *StepsDefinition[T]) SetDurations(durations ...time.Duration) T {
+	d.durations = slices.Clone(durations)
+	return d.Self()
+}
+
+func (d *StepsDefinition[T]) GetDurations() []time.Duration {
+	return slices.Clone(d.durations)
+}
+
+// UseHistory seeds the expected step durations with the durations
+// recorded for the given name in previous runs, and records
+// the actual durations.
+func (d *StepsDefinition[T]) UseHistory(h History, name string) T {
+	d.history = h
+	d.name = name
+	return d.Self()
+}
+
 func (d *StepsDefinition[T]) GetTotal() int {
 	return len(d.steps)
 }
@@ -63,6 +105,9 @@ func (d *StepsDefinition[T]) GetTotal() int {
 type StepsSpecification[T any] interface {
 	BarBaseSpecification[T]
 	SetSteps(steps []string) T
+	SetWeights(w ...float64) T
+	SetDurations(d ...time.Duration) T
+	UseHistory(h History, name string) T
 	AppendStep() T
 	PrependStep() T
 }
@@ -70,4 +115,7 @@ type StepsSpecification[T any] interface {
 type StepsConfiguration interface {
 	BarConfiguration[time.Duration]
 	GetSteps() []string
+	GetWeights() []float64
+	GetDurations() []time.Duration
+	HistoryProvider
 }
@@ -14,7 +14,7 @@ type TextInterface interface {
 
 type TextDefinition[T any] struct {
 	ElementDefinition[T]
-	view int
+	view *int
 
 	auto        bool
 	titleline   string
@@ -22,6 +22,9 @@ type TextDefinition[T any] struct {
 	viewFormat  ttycolors.Format
 	gap         string
 	followupgap string
+	linePrefix  LinePrefixFunc
+	lineFormat  LineFormatFunc
+	viewFilter  LineFilterFunc
 }
 
 var (
@@ -32,7 +35,7 @@ var (
 // NewTextDefinition can be used to create a nested definition
 // for a derived text definition.
 func NewTextDefinition[T any](self Self[T]) TextDefinition[T] {
-	d := TextDefinition[T]{view: TextView}
+	d := TextDefinition[T]{}
 	d.ElementDefinition = NewElementDefinition(self)
 	return d
 }
@@ -44,12 +47,15 @@ func (d *TextDefinition[T]) Dup(s Self[T]) TextDefinition[T] {
 }
 
 func (d *TextDefinition[T]) SetView(v int) T {
-	d.view = v
+	d.view = &v
 	return d.Self()
 }
 
 func (d *TextDefinition[T]) GetView() int {
-	return d.view
+	if d.view == nil {
+		return d.effectiveTheme().TextView
+	}
+	return *d.view
 }
 
 func (d *TextDefinition[T]) SetViewFormat(f ...ttycolors.FormatProvider) T {
@@ -67,6 +73,9 @@ func (d *TextDefinition[T]) SetTitleFormat(f ...ttycolors.FormatProvider) T {
 }
 
 func (d *TextDefinition[T]) GetTitleFormat() ttycolors.Format {
+	if d.titleFormat == nil {
+		return d.effectiveTheme().TitleFormat
+	}
 	return d.titleFormat
 }
 
@@ -106,6 +115,40 @@ func (d *TextDefinition[T]) GetTitleLine() string {
 	return d.titleline
 }
 
+// SetLinePrefix sets a function providing a prefix for
+// every line of the content, for example a timestamp.
+func (d *TextDefinition[T]) SetLinePrefix(f LinePrefixFunc) T {
+	d.linePrefix = f
+	return d.Self()
+}
+
+func (d *TextDefinition[T]) GetLinePrefix() LinePrefixFunc {
+	return d.linePrefix
+}
+
+// SetLineFormat sets a function providing a format for
+// every line of the content, chosen by the line content.
+func (d *TextDefinition[T]) SetLineFormat(f LineFormatFunc) T {
+	d.lineFormat = f
+	return d.Self()
+}
+
+func (d *TextDefinition[T]) GetLineFormat() LineFormatFunc {
+	return d.lineFormat
+}
+
+// SetViewFilter sets a function selecting the lines
+// shown in the view window. After the element is closed
+// the complete output is shown.
+func (d *TextDefinition[T]) SetViewFilter(f LineFilterFunc) T {
+	d.viewFilter = f
+	return d.Self()
+}
+
+func (d *TextDefinition[T]) GetViewFilter() LineFilterFunc {
+	return d.viewFilter
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type TextSpecification[T any] interface {
@@ -120,6 +163,9 @@ type TextSpecification[T any] interface {
 	SetTitleFormat(f ...ttycolors.FormatProvider) T
 	SetAuto(b ...bool) T
 	SetView(int) T
+	SetLinePrefix(f LinePrefixFunc) T
+	SetLineFormat(f LineFormatFunc) T
+	SetViewFilter(f LineFilterFunc) T
 }
 
 type TextConfiguration interface {
@@ -129,6 +175,9 @@ type TextConfiguration interface {
 	FollowupGapProvider
 	ViewFormatProvider
 	TitleFormatProvider
+	LinePrefixProvider
+	LineFormatProvider
+	ViewFilterProvider
 	GetView() int
 	GetAuto() bool
 }
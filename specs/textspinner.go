@@ -1,6 +1,8 @@
 package specs
 
 import (
+	"time"
+
 	"github.com/mandelsoft/ttycolors"
 )
 
@@ -12,9 +14,12 @@ type TextSpinnerInterface interface {
 type TextSpinnerDefinition[T any] struct {
 	SpinnerDefinition[T]
 
-	view       int
+	view       *int
 	viewFormat ttycolors.Format
 	gap        string
+	linePrefix LinePrefixFunc
+	lineFormat LineFormatFunc
+	viewFilter LineFilterFunc
 }
 
 var (
@@ -25,7 +30,7 @@ var (
 // NewTextSpinnerDefinition can be used to create a nested definition
 // for a derived text spinner definition.
 func NewTextSpinnerDefinition[T any](self Self[T]) TextSpinnerDefinition[T] {
-	d := TextSpinnerDefinition[T]{view: 3}
+	d := TextSpinnerDefinition[T]{}
 	d.SpinnerDefinition = NewSpinnerDefinition(self)
 	return d
 }
@@ -36,13 +41,23 @@ func (d *TextSpinnerDefinition[T]) Dup(s Self[T]) TextSpinnerDefinition[T] {
 	return dup
 }
 
+// GetStallTimeout provides the stall timeout. Text spinners
+// record progress with every write, therefore the stall timeout
+// of the theme is used in any case.
+func (d *TextSpinnerDefinition[T]) GetStallTimeout() time.Duration {
+	return d.ProgressDefinition.GetStallTimeout()
+}
+
 func (d *TextSpinnerDefinition[T]) SetView(view int) T {
-	d.view = view
+	d.view = &view
 	return d.Self()
 }
 
 func (d *TextSpinnerDefinition[T]) GetView() int {
-	return d.view
+	if d.view == nil {
+		return d.effectiveTheme().TextView
+	}
+	return *d.view
 }
 
 func (d *TextSpinnerDefinition[T]) SetViewFormat(f ...ttycolors.FormatProvider) T {
@@ -63,6 +78,40 @@ func (d *TextSpinnerDefinition[T]) GetFollowUpGap() string {
 	return d.gap
 }
 
+// SetLinePrefix sets a function providing a prefix for
+// every line of the content, for example a timestamp.
+func (d *TextSpinnerDefinition[T]) SetLinePrefix(f LinePrefixFunc) T {
+	d.linePrefix = f
+	return d.Self()
+}
+
+func (d *TextSpinnerDefinition[T]) GetLinePrefix() LinePrefixFunc {
+	return d.linePrefix
+}
+
+// SetLineFormat sets a function providing a format for
+// every line of the content, chosen by the line content.
+func (d *TextSpinnerDefinition[T]) SetLineFormat(f LineFormatFunc) T {
+	d.lineFormat = f
+	return d.Self()
+}
+
+func (d *TextSpinnerDefinition[T]) GetLineFormat() LineFormatFunc {
+	return d.lineFormat
+}
+
+// SetViewFilter sets a function selecting the lines
+// shown in the view window. After the element is closed
+// the complete output is shown.
+func (d *TextSpinnerDefinition[T]) SetViewFilter(f LineFilterFunc) T {
+	d.viewFilter = f
+	return d.Self()
+}
+
+func (d *TextSpinnerDefinition[T]) GetViewFilter() LineFilterFunc {
+	return d.viewFilter
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type TextSpinnerSpecification[T any] interface {
@@ -70,11 +119,17 @@ type TextSpinnerSpecification[T any] interface {
 	SetView(view int) T
 	SetFollowUpGap(gap string) T
 	SetViewFormat(f ...ttycolors.FormatProvider) T
+	SetLinePrefix(f LinePrefixFunc) T
+	SetLineFormat(f LineFormatFunc) T
+	SetViewFilter(f LineFilterFunc) T
 }
 
 type TextSpinnerConfiguration interface {
 	SpinnerConfiguration
 	FollowupGapProvider
 	ViewFormatProvider
+	LinePrefixProvider
+	LineFormatProvider
+	ViewFilterProvider
 	GetView() int
 }
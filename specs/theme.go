@@ -0,0 +1,167 @@
+package specs
+
+import (
+	"slices"
+	"time"
+
+	"github.com/mandelsoft/ttycolors"
+	"github.com/mandelsoft/ttyprogress/units"
+)
+
+// Theme describes the default settings used by element definitions
+// as long as they are not explicitly configured for a definition.
+// A Theme can be attached to a Context, a group or a single definition.
+// It is inherited by all definitions added beneath, unless
+// they provide an own Theme.
+type Theme struct {
+	// Done is the message shown by spinners after they are closed.
+	Done string
+	// Failed is the message shown by spinners after they failed.
+	Failed string
+	// Skipped is the message shown for skipped nested steps.
+	Skipped string
+	// Pending is the message shown by bars before they are started.
+	Pending string
+	// Paused is the marker shown by progress indicators while they are paused.
+	Paused string
+	// StallTimeout is the time without progress after which progress
+	// indicators are considered to be stalled. 0 disables the stall detection.
+	StallTimeout time.Duration
+
+	// BarWidth is the width of the progress bar visualization.
+	BarWidth uint
+	// BarConfig is the visualization of progress bars.
+	BarConfig BarConfig
+
+	// SpinnerSpeed is the phase change speed of spinners.
+	SpinnerSpeed int
+	// SpinnerPhases is the set of phases used by spinners.
+	SpinnerPhases []string
+
+	// TextView is the number of lines shown by text elements.
+	TextView int
+
+	// GroupGap is the gap used for the first line of group members.
+	GroupGap string
+	// GroupFollowUpGap is the gap used for additional lines of group members.
+	GroupFollowUpGap string
+	// TreeConnectors are the connectors used by groups in tree mode.
+	TreeConnectors TreeConnectors
+	// Folded is the format string for the summary line of folded
+	// group members. It gets the number of folded members.
+	Folded string
+
+	// TitleFormat is the format used for title lines of text elements.
+	TitleFormat ttycolors.Format
+	// ProgressFormat is the format used for the progress visualization.
+	ProgressFormat ttycolors.Format
+	// SuccessFormat is the format used for the progress visualization
+	// of finished elements.
+	SuccessFormat ttycolors.Format
+	// FailureFormat is the format used for the progress visualization
+	// of failed elements.
+	FailureFormat ttycolors.Format
+	// StalledFormat is the format used for the progress visualization
+	// of stalled elements.
+	StalledFormat ttycolors.Format
+
+	// DurationFormat is the format used by decorators showing durations,
+	// like the elapsed time. By default, PrettyTime is used.
+	DurationFormat units.DurationFormat
+}
+
+var defaultTheme = NewTheme()
+
+// NewTheme provides a new Theme initialized with the
+// built-in defaults.
+func NewTheme() *Theme {
+	return &Theme{
+		Done:             Done,
+		Failed:           Failed,
+		Skipped:          Skipped,
+		Pending:          Pending,
+		Paused:           Paused,
+		BarWidth:         BarWidth,
+		BarConfig:        BarTypes[BarType],
+		SpinnerSpeed:     SpinnerSpeed,
+		SpinnerPhases:    slices.Clone(SpinnerTypes[SpinnerType]),
+		TextView:         TextView,
+		GroupGap:         GroupGap,
+		GroupFollowUpGap: GroupFollowUpGap,
+		TreeConnectors:   DefaultTreeConnectors,
+		Folded:           Folded,
+	}
+}
+
+// Dup provides an independent copy of the Theme.
+func (t *Theme) Dup() *Theme {
+	dup := *t
+	dup.SpinnerPhases = slices.Clone(t.SpinnerPhases)
+	return &dup
+}
+
+// SetBarType sets the bar visualization to a predefined
+// configuration taken from BarTypes.
+func (t *Theme) SetBarType(i int) *Theme {
+	if c, ok := BarTypes[i]; ok {
+		t.BarConfig = c
+	}
+	return t
+}
+
+// SetSpinnerType sets the spinner phases to a predefined
+// set taken from SpinnerTypes.
+func (t *Theme) SetSpinnerType(i int) *Theme {
+	if c, ok := SpinnerTypes[i]; ok {
+		t.SpinnerPhases = slices.Clone(c)
+	}
+	return t
+}
+
+// ThemeProvider is the optional interface of containers
+// and definitions providing a Theme.
+type ThemeProvider interface {
+	GetTheme() *Theme
+}
+
+// ThemeFor provides the Theme of an object, if it is a ThemeProvider.
+// Otherwise, nil is returned.
+func ThemeFor(o any) *Theme {
+	if p, ok := o.(ThemeProvider); ok {
+		return p.GetTheme()
+	}
+	return nil
+}
+
+// EffectiveTheme provides the given Theme or the
+// built-in default Theme, if nil.
+func EffectiveTheme(t *Theme) *Theme {
+	if t == nil {
+		return defaultTheme
+	}
+	return t
+}
+
+// ThemedDefinition is the interface of definitions
+// able to inherit a Theme from the container they are added to.
+type ThemedDefinition[D any] interface {
+	Dup() D
+	GetTheme() *Theme
+	inheritTheme(t *Theme)
+}
+
+// InheritTheme provides a definition using the Theme of the
+// given container, if the definition does not provide an own Theme.
+// The original definition is not modified.
+func InheritTheme[D ThemedDefinition[D]](d D, c Container) D {
+	if d.GetTheme() != nil {
+		return d
+	}
+	t := ThemeFor(c)
+	if t == nil {
+		return d
+	}
+	d = d.Dup()
+	d.inheritTheme(t)
+	return d
+}
@@ -0,0 +1,19 @@
+package specs
+
+// TreeConnectors describes the connectors used to
+// render the members of groups in tree mode.
+type TreeConnectors struct {
+	// Branch is used for the first line of a member followed by further members.
+	Branch string
+	// Last is used for the first line of the last member.
+	Last string
+	// Line is used for additional lines of a member followed by further members.
+	Line string
+	// Space is used for additional lines of the last member.
+	Space string
+}
+
+var (
+	DefaultTreeConnectors = TreeConnectors{Branch: "├─ ", Last: "└─ ", Line: "│  ", Space: "   "}
+	ASCIITreeConnectors   = TreeConnectors{Branch: "|- ", Last: "`- ", Line: "|  ", Space: "   "}
+)
@@ -3,12 +3,15 @@ package specs
 import (
 	"fmt"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/mandelsoft/goutils/general"
 	"github.com/mandelsoft/goutils/sliceutils"
 	"github.com/mandelsoft/goutils/stringutils"
 	"github.com/mandelsoft/ttycolors"
+	"github.com/mandelsoft/ttyprogress/blocks"
 	"github.com/mandelsoft/ttyprogress/types"
 	"github.com/mandelsoft/ttyprogress/units"
 )
@@ -46,6 +49,36 @@ func PrettyTime(t time.Duration) string {
 	return units.Seconds(int(t.Truncate(time.Second) / time.Second))
 }
 
+// DurationFormatProvider is the optional interface of elements
+// providing a default format for durations.
+type DurationFormatProvider interface {
+	GetDurationFormat() units.DurationFormat
+}
+
+// FormatDuration formats a duration shown by a decorator with the
+// given format or the default format of the element. If no format
+// is configured, PrettyTime padded to 5 characters is used.
+// If the duration is not available, blanks of the width of
+// a formatted zero duration are provided.
+func FormatDuration(e ElementState, f units.DurationFormat, d time.Duration, ok bool) string {
+	if f == nil {
+		if p, ok := e.(DurationFormatProvider); ok {
+			f = p.GetDurationFormat()
+		}
+	}
+	if f == nil {
+		s := ""
+		if ok {
+			s = PrettyTime(d)
+		}
+		return stringutils.PadLeft(s, 5, ' ')
+	}
+	if !ok {
+		return strings.Repeat(" ", len(f(0)))
+	}
+	return f(d)
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type formattedDecoratorDefinition struct {
@@ -79,10 +112,81 @@ func Formatted(def DecoratorDefinition, f ...ttycolors.FormatProvider) Decorator
 
 ////////////////////////////////////////////////////////////////////////////////
 
+type shrinkableDecoratorDefinition struct {
+	min int
+	def DecoratorDefinition
+}
+
+func (d *shrinkableDecoratorDefinition) CreateDecorator(e ElementState) types.Decorator {
+	return &shrinkableDecorator{min: d.min, deco: d.def.CreateDecorator(e)}
+}
+
+type shrinkableDecorator struct {
+	min    int
+	shrink int
+	deco   types.Decorator
+}
+
+var _ types.Shrinker = (*shrinkableDecorator)(nil)
+
+func (d *shrinkableDecorator) Shrink(n int) int {
+	d.shrink = 0
+	if n <= 0 {
+		return 0
+	}
+	if s, ok := d.text(); ok {
+		d.shrink = max(0, min(n, blocks.StringWidth(s)-d.min))
+	}
+	return d.shrink
+}
+
+// plain provides a context used to render the decorated
+// value without formats.
+var plain = ttycolors.NewContext(false)
+
+func (d *shrinkableDecorator) text() (string, bool) {
+	switch v := d.deco.Decorate().(type) {
+	case nil:
+		return "", false
+	case ttycolors.String:
+		return plain.String(v).String(), true
+	case string:
+		return v, true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+func (d *shrinkableDecorator) Decorate() any {
+	if d.shrink > 0 {
+		if s, ok := d.text(); ok {
+			return blocks.TruncateMiddle(s, blocks.StringWidth(s)-d.shrink)
+		}
+	}
+	return d.deco.Decorate()
+}
+
+func (d *shrinkableDecorator) Unwrap() any {
+	return d.deco
+}
+
+// Shrinkable marks a decorator to be shortened from the middle
+// using an ellipsis, if the progress line exceeds the terminal width
+// (for example for long file names). The output is kept at least
+// min characters long (default 1).
+// Formats provided by the wrapped decorator are dropped, if the
+// output is shortened. Therefore, formats should be applied to the
+// shrinkable decorator.
+func Shrinkable(def DecoratorDefinition, min ...int) DecoratorDefinition {
+	return &shrinkableDecoratorDefinition{general.OptionalDefaulted(1, min...), def}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type scrollingText struct {
 	gap    string
 	length int
-	text   string
+	text   []rune
 	offset int
 	speed  *Speed
 }
@@ -91,14 +195,26 @@ var _ types.Ticker
 
 func (s *scrollingText) Tick() bool {
 	if s.speed.Tick() {
-		s.offset = (s.offset + 1) % (len(s.text) + s.length)
+		s.offset = (s.offset + 1) % len(s.text)
 		return true
 	}
 	return false
 }
 
 func (s *scrollingText) Decorate() any {
-	return (s.text + s.text)[s.offset : s.offset+s.length]
+	var buf strings.Builder
+
+	w := 0
+	for i := s.offset; ; i++ {
+		r := s.text[i%len(s.text)]
+		rw := blocks.RuneWidth(r)
+		if w+rw > s.length {
+			break
+		}
+		buf.WriteRune(r)
+		w += rw
+	}
+	return blocks.PadRight(buf.String(), s.length)
 }
 
 type scrollingTextDef struct {
@@ -109,14 +225,14 @@ type scrollingTextDef struct {
 
 func (s *scrollingTextDef) CreateDecorator(e ElementState) types.Decorator {
 	t := s.text
-	if len(s.text) <= s.length {
-		return Message(stringutils.PadRight(t, s.length, ' ')).CreateDecorator(e)
+	if blocks.StringWidth(s.text) <= s.length {
+		return Message(blocks.PadRight(t, s.length)).CreateDecorator(e)
 	}
 	t += " "
 	return &scrollingText{
 		gap:    fmt.Sprintf("%-*s", s.length, " "),
 		length: s.length,
-		text:   t,
+		text:   []rune(t),
 		speed:  NewSpeed(s.speed),
 	}
 }
@@ -36,7 +36,7 @@ func (d *SpinnerDefinition) Dup() *SpinnerDefinition {
 }
 
 func (d *SpinnerDefinition) Add(c Container) (Spinner, error) {
-	return newSpinner(c, d)
+	return newSpinner(c, specs.InheritTheme(d, c))
 }
 
 ////////////////////////////////////////////////////////////////////////////////
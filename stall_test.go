@@ -0,0 +1,116 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+var _ = Describe("Stall Detection Test Environment", func() {
+	var p ttyprogress.Context
+
+	BeforeEach(func() {
+		p = ttyprogress.For(&bytes.Buffer{})
+	})
+
+	AfterEach(func() {
+		p.Close()
+	})
+
+	It("detects stalled elements", func() {
+		bar, err := ttyprogress.NewBar().SetStallTimeout(50 * time.Millisecond).Add(p)
+		Expect(err).To(Succeed())
+		bar.Start()
+		Expect(bar.IsStalled()).To(BeFalse())
+		Expect(bar.TimeStalled()).To(BeZero())
+
+		Eventually(bar.IsStalled).Should(BeTrue())
+		Expect(bar.TimeStalled()).To(BeNumerically(">=", 50*time.Millisecond))
+
+		bar.Touch()
+		Expect(bar.IsStalled()).To(BeFalse())
+		Eventually(bar.IsStalled).Should(BeTrue())
+		bar.Incr()
+		Expect(bar.IsStalled()).To(BeFalse())
+		bar.Close()
+	})
+
+	It("does not count paused time", func() {
+		bar, err := ttyprogress.NewBar().SetStallTimeout(50 * time.Millisecond).Add(p)
+		Expect(err).To(Succeed())
+		bar.Start()
+		bar.Pause()
+		time.Sleep(100 * time.Millisecond)
+		Expect(bar.IsStalled()).To(BeFalse())
+		bar.Resume()
+		Expect(bar.IsStalled()).To(BeFalse())
+		bar.Close()
+	})
+
+	Context("spinners", func() {
+		BeforeEach(func() {
+			theme := ttyprogress.NewTheme()
+			theme.StallTimeout = 50 * time.Millisecond
+			p.SetTheme(theme)
+		})
+
+		It("ignores the theme timeout for plain spinners", func() {
+			s, err := ttyprogress.NewSpinner().Add(p)
+			Expect(err).To(Succeed())
+			s.Start()
+			Consistently(s.IsStalled, "150ms").Should(BeFalse())
+			s.Close()
+		})
+
+		It("uses the theme timeout in heartbeat mode", func() {
+			s, err := ttyprogress.NewSpinner().SetHeartbeat().Add(p)
+			Expect(err).To(Succeed())
+			s.Start()
+			Eventually(s.IsStalled).Should(BeTrue())
+			s.Beat()
+			Expect(s.IsStalled()).To(BeFalse())
+			s.Close()
+		})
+
+		It("uses an explicit timeout", func() {
+			s, err := ttyprogress.NewSpinner().SetStallTimeout(50 * time.Millisecond).Add(p)
+			Expect(err).To(Succeed())
+			s.Start()
+			Eventually(s.IsStalled).Should(BeTrue())
+			s.Close()
+		})
+	})
+
+	It("calls the stall handler once per stall", func() {
+		var lock sync.Mutex
+		var stalled []ttyprogress.ProgressElement
+		p.OnStall(func(e ttyprogress.ProgressElement, d time.Duration) {
+			lock.Lock()
+			defer lock.Unlock()
+			stalled = append(stalled, e)
+		})
+		count := func() int {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(stalled)
+		}
+
+		bar, err := ttyprogress.NewBar().SetStallTimeout(50 * time.Millisecond).Add(p)
+		Expect(err).To(Succeed())
+		bar.Start()
+		Eventually(count).Should(Equal(1))
+		Consistently(count, "100ms").Should(Equal(1))
+
+		bar.Incr()
+		Eventually(count).Should(Equal(2))
+		lock.Lock()
+		Expect(stalled[0]).To(BeIdenticalTo(stalled[1]))
+		lock.Unlock()
+		bar.Close()
+	})
+})
@@ -1,8 +1,12 @@
 package ttyprogress
 
 import (
-	"github.com/mandelsoft/goutils/stringutils"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/mandelsoft/object"
+	"github.com/mandelsoft/ttyprogress/blocks"
 	"github.com/mandelsoft/ttyprogress/specs"
 )
 
@@ -28,7 +32,7 @@ func (d *StepsDefinition) Dup() *StepsDefinition {
 }
 
 func (d *StepsDefinition) Add(c Container) (Steps, error) {
-	return newSteps(c, d)
+	return newSteps(c, specs.InheritTheme(d, c))
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -44,16 +48,60 @@ func (s *_Steps) GetCurrentStep() string {
 	return s.elem.Protected().GetCurrentStep()
 }
 
+// SetSteps replaces the list of steps. The total of the
+// progress bar is adjusted to the new number of steps.
+func (s *_Steps) SetSteps(steps ...string) {
+	defer s.elem.Lock()()
+
+	s.elem.Protected().SetSteps(steps...)
+}
+
+func (s *_Steps) TimeRemaining() (time.Duration, bool) {
+	defer s.elem.Lock()()
+
+	return s.elem.Protected().TimeRemaining()
+}
+
+// hideTitle suppresses the step title
+// provided by GetCurrentStep.
+func (s *_Steps) hideTitle() {
+	defer s.elem.Lock()()
+
+	s.elem.Protected().untitled = true
+}
+
+func (s *_Steps) setWeights(weights []float64, durations []time.Duration) {
+	defer s.elem.Lock()()
+
+	s.elem.Protected().weights = weights
+	s.elem.Protected().durations = durations
+	s.elem.Protected().seedDurations()
+}
+
 type _StepsImpl struct {
 	*IntBarBaseImpl[*_StepsImpl]
-	steps []string
+	steps     []string
+	names     []string
+	weights   []float64
+	durations []time.Duration
+	history   specs.History
+	name      string
+
+	// untitled suppresses the step title.
+	untitled bool
+
+	// stepStarted is the elapsed time of the element
+	// when the current step has been started.
+	stepStarted time.Duration
 }
 
 // NewSteps create a Steps progress information for a given
 // list of sequential steps.
 func newSteps(p Container, c specs.StepsConfiguration) (Steps, error) {
-	steps := stringutils.AlignLeft(c.GetSteps(), ' ')
-	e := &_StepsImpl{steps: steps}
+	steps := blocks.AlignLeft(c.GetSteps())
+	e := &_StepsImpl{steps: steps, names: c.GetSteps(), weights: c.GetWeights(), durations: c.GetDurations()}
+	e.history, e.name = c.GetHistory()
+	e.seedDurations()
 	o := &_Steps{elem: e}
 
 	b, s, err := newIntBar[*_StepsImpl](p, c, len(steps), object.NewSelf[*_StepsImpl, any](e, o))
@@ -65,13 +113,163 @@ func newSteps(p Container, c specs.StepsConfiguration) (Steps, error) {
 	return o, nil
 }
 
+func (s *_StepsImpl) SetSteps(steps ...string) {
+	s.steps = blocks.AlignLeft(steps)
+	s.names = slices.Clone(steps)
+	s.seedDurations()
+	s.SetTotal(len(steps))
+	s.Protected().Flush()
+}
+
 func (s *_StepsImpl) GetCurrentStep() string {
+	if s.untitled {
+		return ""
+	}
 	c := s.Current()
 	if c == 0 && !s.IsStarted() {
-		return stringutils.PadRight("", len(s.steps[0]), ' ')
+		return s.blank()
 	}
 	if c < len(s.steps) {
 		return s.steps[c]
 	}
-	return stringutils.PadRight("", len(s.steps[0]), ' ')
+	return s.blank()
+}
+
+func (s *_StepsImpl) Incr() bool {
+	cur, started, elapsed := s.Current(), s.IsStarted(), s.stepElapsed()
+	last := s.stepStarted
+	s.stepStarted = s.TimeElapsed()
+	if !s.IntBarBaseImpl.Incr() {
+		s.stepStarted = last
+		return false
+	}
+	if started {
+		s.record(cur, elapsed)
+	}
+	return true
+}
+
+func (s *_StepsImpl) Set(n int) bool {
+	last := s.stepStarted
+	if n != s.Current() {
+		s.stepStarted = s.TimeElapsed()
+	}
+	if !s.IntBarBaseImpl.Set(n) {
+		s.stepStarted = last
+		return false
+	}
+	return true
+}
+
+func (s *_StepsImpl) ResetProgress() {
+	s.IntBarBaseImpl.ResetProgress()
+	s.stepStarted = s.TimeElapsed()
+}
+
+// weight provides the weight of a step. If weights are given,
+// steps without weight count with 1. Otherwise, the expected
+// durations are used as weights, steps without duration
+// count as one second. Both scales are never mixed.
+func (s *_StepsImpl) weight(i int) float64 {
+	if len(s.weights) > 0 {
+		if i < len(s.weights) && s.weights[i] > 0 {
+			return s.weights[i]
+		}
+		return 1
+	}
+	if d := s.duration(i); d > 0 {
+		return d.Seconds()
+	}
+	return 1
+}
+
+func (s *_StepsImpl) duration(i int) time.Duration {
+	if i < len(s.durations) {
+		return s.durations[i]
+	}
+	return 0
+}
+
+// CompletedPercent provides the percentage of the weights
+// of the completed steps.
+func (s *_StepsImpl) CompletedPercent() float64 {
+	if len(s.weights) == 0 && len(s.durations) == 0 {
+		return s.IntBarBaseImpl.CompletedPercent()
+	}
+	total, done := 0.0, 0.0
+	for i := 0; i < s.Total(); i++ {
+		w := s.weight(i)
+		total += w
+		if i < s.Current() {
+			done += w
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return done / total * 100
+}
+
+// TimeRemaining provides the expected remaining time based on
+// the expected durations of the outstanding steps and the time
+// already spent for the current step.
+func (s *_StepsImpl) TimeRemaining() (time.Duration, bool) {
+	if len(s.durations) == 0 {
+		return 0, false
+	}
+	var d time.Duration
+	for i := s.Current(); i < s.Total(); i++ {
+		d += s.duration(i)
+	}
+	if s.IsStarted() && s.Current() < s.Total() {
+		d -= min(s.stepElapsed(), s.duration(s.Current()))
+	}
+	return d, true
+}
+
+// stepElapsed provides the time spent for the current step.
+func (s *_StepsImpl) stepElapsed() time.Duration {
+	return s.TimeElapsed() - s.stepStarted
+}
+
+// seedDurations uses the durations recorded in the history
+// for steps without an expected duration.
+func (s *_StepsImpl) seedDurations() {
+	if s.history == nil {
+		return
+	}
+	durations := make([]time.Duration, len(s.names))
+	seeded := false
+	for i, n := range s.names {
+		durations[i] = s.duration(i)
+		if durations[i] == 0 {
+			if d, ok := s.history.Estimate(s.key(n)); ok {
+				durations[i] = d
+				seeded = true
+			}
+		}
+	}
+	if seeded {
+		s.durations = durations
+	}
+}
+
+// record records the actual duration of a finished step in the history.
+func (s *_StepsImpl) record(i int, d time.Duration) {
+	if s.history != nil && i < len(s.names) {
+		s.history.Record(s.key(s.names[i]), d)
+	}
+}
+
+// key provides the history key for a step. Padding added
+// to align the step names is not part of the key.
+func (s *_StepsImpl) key(step string) string {
+	return s.name + "/" + strings.TrimRight(step, " ")
+}
+
+func (s *_StepsImpl) blank() string {
+	if len(s.steps) == 0 {
+		return ""
+	}
+	return blocks.PadRight("", blocks.StringWidth(s.steps[0]))
 }
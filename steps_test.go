@@ -0,0 +1,76 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+var _ = Describe("Steps Test Environment", func() {
+	var p ttyprogress.Context
+
+	BeforeEach(func() {
+		p = ttyprogress.For(&bytes.Buffer{})
+	})
+
+	AfterEach(func() {
+		p.Close()
+	})
+
+	It("counts steps without weight with 1", func() {
+		s, err := ttyprogress.NewSteps("a", "b", "c").SetWeights(1, 3).Add(p)
+		Expect(err).To(Succeed())
+		s.Start()
+		Expect(s.CompletedPercent()).To(Equal(0.0))
+		s.Incr()
+		Expect(s.CompletedPercent()).To(BeNumerically("~", 20, 0.001))
+		s.Incr()
+		Expect(s.CompletedPercent()).To(BeNumerically("~", 80, 0.001))
+		s.Close()
+	})
+
+	It("uses the durations as weights", func() {
+		s, err := ttyprogress.NewSteps("a", "b", "c").SetDurations(time.Minute, 3*time.Minute).Add(p)
+		Expect(err).To(Succeed())
+		s.Start()
+		s.Incr()
+		Expect(s.CompletedPercent()).To(BeNumerically("~", 60.0/(60+180+1)*100, 0.001))
+		s.Close()
+	})
+
+	It("does not mix weights and durations", func() {
+		s, err := ttyprogress.NewSteps("a", "b").SetWeights(2).SetDurations(time.Minute, time.Hour).Add(p)
+		Expect(err).To(Succeed())
+		s.Start()
+		s.Incr()
+		Expect(s.CompletedPercent()).To(BeNumerically("~", 200.0/3, 0.001))
+		s.Close()
+	})
+
+	It("provides the remaining time", func() {
+		s, err := ttyprogress.NewSteps("a", "b").SetDurations(time.Minute, 2*time.Minute).Add(p)
+		Expect(err).To(Succeed())
+		s.Start()
+		d, ok := s.TimeRemaining()
+		Expect(ok).To(BeTrue())
+		Expect(d).To(BeNumerically("~", 3*time.Minute, time.Second))
+		s.Incr()
+		d, ok = s.TimeRemaining()
+		Expect(ok).To(BeTrue())
+		Expect(d).To(BeNumerically("~", 2*time.Minute, time.Second))
+		s.Close()
+	})
+
+	It("provides no remaining time without durations", func() {
+		s, err := ttyprogress.NewSteps("a", "b").SetWeights(1, 2).Add(p)
+		Expect(err).To(Succeed())
+		s.Start()
+		_, ok := s.TimeRemaining()
+		Expect(ok).To(BeFalse())
+		s.Close()
+	})
+})
@@ -40,7 +40,7 @@ func (d *TextDefinition) Dup() *TextDefinition {
 }
 
 func (d *TextDefinition) Add(c Container) (Text, error) {
-	return newText(c, d)
+	return newText(c, specs.InheritTheme(d, c))
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -30,7 +30,7 @@ func (d *TextSpinnerDefinition) Dup() *TextSpinnerDefinition {
 }
 
 func (d *TextSpinnerDefinition) Add(c Container) (TextSpinner, error) {
-	return newTextSpinner(c, d)
+	return newTextSpinner(c, specs.InheritTheme(d, c))
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -72,6 +72,7 @@ func newTextSpinner(p Container, c specs.TextSpinnerConfiguration) (TextSpinner,
 
 func (b *_TextSpinnerImpl) Write(data []byte) (int, error) {
 	b.Start()
+	b.Touch()
 	return b.Block().Write(data)
 }
 
@@ -0,0 +1,17 @@
+package ttyprogress
+
+import (
+	"github.com/mandelsoft/ttyprogress/specs"
+)
+
+// Theme describes the defaults used for element definitions,
+// which are not explicitly configured.
+// It can be set for a Context, a group or a definition and is
+// inherited by all elements added beneath.
+type Theme = specs.Theme
+
+// NewTheme provides a new Theme initialized with the built-in
+// defaults, which can be refined afterwards.
+func NewTheme() *Theme {
+	return specs.NewTheme()
+}
@@ -0,0 +1,97 @@
+package ttyprogress_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/ttyprogress"
+)
+
+// done provides a Theme with the given done message.
+func done(msg string) *ttyprogress.Theme {
+	t := ttyprogress.NewTheme()
+	t.Done = msg
+	return t
+}
+
+var _ = Describe("Theme Test Environment", func() {
+	var buf *syncBuffer
+	var p ttyprogress.Context
+
+	BeforeEach(func() {
+		buf = &syncBuffer{}
+		p = ttyprogress.For(buf).SetTheme(done("context"))
+	})
+
+	// lines closes the context and provides the final
+	// lines with the given prefix.
+	lines := func(prefix string) []string {
+		p.Close()
+		p.Wait(context.Background())
+		var r []string
+		for _, l := range buf.Screen() {
+			if i := strings.Index(l, prefix); i >= 0 {
+				r = append(r, l[i:])
+			}
+		}
+		return r
+	}
+
+	It("uses the theme of the context", func() {
+		s, err := ttyprogress.NewSpinner().PrependMessage("spinner").Add(p)
+		Expect(err).To(Succeed())
+		s.Close()
+		Expect(lines("spinner")).To(Equal([]string{"spinner context"}))
+	})
+
+	It("inherits the theme through groups", func() {
+		g, err := ttyprogress.NewAnonymousGroup().Add(p)
+		Expect(err).To(Succeed())
+		s, err := ttyprogress.NewSpinner().PrependMessage("spinner").Add(g)
+		Expect(err).To(Succeed())
+		s.Close()
+		g.Close()
+		Expect(lines("spinner")).To(Equal([]string{"spinner context"}))
+	})
+
+	It("uses the theme of a group", func() {
+		g, err := ttyprogress.NewAnonymousGroup().SetTheme(done("group")).Add(p)
+		Expect(err).To(Succeed())
+		s, err := ttyprogress.NewSpinner().PrependMessage("spinner").Add(g)
+		Expect(err).To(Succeed())
+		s.Close()
+		g.Close()
+		Expect(lines("spinner")).To(Equal([]string{"spinner group"}))
+	})
+
+	It("uses the theme of a definition", func() {
+		g, err := ttyprogress.NewAnonymousGroup().SetTheme(done("group")).Add(p)
+		Expect(err).To(Succeed())
+		def := ttyprogress.NewSpinner().PrependMessage("spinner").SetTheme(done("definition"))
+		s, err := def.Add(g)
+		Expect(err).To(Succeed())
+		s.Close()
+		g.Close()
+		Expect(lines("spinner")).To(Equal([]string{"spinner definition"}))
+		Expect(def.GetTheme().Done).To(Equal("definition"))
+	})
+
+	It("prefers explicit settings", func() {
+		s, err := ttyprogress.NewSpinner().PrependMessage("spinner").SetDone("explicit").Add(p)
+		Expect(err).To(Succeed())
+		s.Close()
+		Expect(lines("spinner")).To(Equal([]string{"spinner explicit"}))
+	})
+
+	It("does not modify the definition", func() {
+		def := ttyprogress.NewSpinner().PrependMessage("spinner")
+		s, err := def.Add(p)
+		Expect(err).To(Succeed())
+		s.Close()
+		Expect(lines("spinner")).To(Equal([]string{"spinner context"}))
+		Expect(def.GetTheme()).To(BeNil())
+	})
+})
@@ -1,6 +1,7 @@
 package ttyprogress
 
 import (
+	"github.com/mandelsoft/ttyprogress/blocks"
 	"github.com/mandelsoft/ttyprogress/specs"
 	"github.com/mandelsoft/ttyprogress/types"
 )
@@ -23,6 +24,20 @@ type ElementSpecification[T Element] interface {
 }
 
 type Ticker = types.Ticker
+type Shrinker = types.Shrinker
+
+// Overflow describes the handling of lines exceeding
+// the terminal width.
+type Overflow = blocks.Overflow
+
+const (
+	// OverflowDefault uses the policy of the Context.
+	OverflowDefault = blocks.OverflowDefault
+	// OverflowWrap wraps long lines.
+	OverflowWrap = blocks.OverflowWrap
+	// OverflowTruncate truncates long lines using an ellipsis.
+	OverflowTruncate = blocks.OverflowTruncate
+)
 
 type Dupper[T any] interface {
 	Dup() T
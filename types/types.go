@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"errors"
 	"io"
 	"time"
 
@@ -9,10 +10,23 @@ import (
 	"github.com/mandelsoft/ttyprogress/blocks"
 )
 
+// ErrFailed is the default error for failed elements.
+var ErrFailed = errors.New("failed")
+
 type Ticker interface {
 	Tick() bool
 }
 
+// Shrinker is the optional interface of decorators,
+// which can be shortened if a progress line exceeds
+// the terminal width.
+type Shrinker interface {
+	// Shrink requests to shorten subsequent outputs by n
+	// characters. It returns the number of characters
+	// actually dropped. Shrink(0) resets the request.
+	Shrink(n int) int
+}
+
 type String ttycolors.String
 
 // DecoratorFunc is a function that can be prepended and appended to the progress bar
@@ -71,6 +85,24 @@ type Element interface {
 	// IsFinished returns whether the progress is done.
 	IsFinished() bool
 
+	// Pause pauses the element. The time the element is paused
+	// is not included in the elapsed time and all time based
+	// information derived from it.
+	Pause()
+
+	// Resume resumes a paused element.
+	Resume()
+
+	// IsPaused reports whether the element is paused.
+	IsPaused() bool
+
+	// Fail closes the element and marks it as failed.
+	// If no error is given, ErrFailed is used.
+	Fail(err error) error
+
+	// GetError returns the error of a failed element.
+	GetError() error
+
 	// TimeElapsed reports the duration this element has been
 	// active (time since Start or between Start and Close).
 	TimeElapsed() time.Duration
@@ -104,4 +136,33 @@ type ProgressElement interface {
 	SetProgressColor(fmt ttycolors.FormatProvider)
 	SetVariable(name string, value any)
 	GetVariable(name string) any
+
+	// Reset resets the progress of a not yet closed element to
+	// start a new attempt and increments the retry counter.
+	// By default, the start time is reset, also. If keepElapsed
+	// is set, the elapsed time covers all attempts.
+	// Elements finished with Close or Fail cannot be reset
+	// (os.ErrClosed). Therefore, a failed attempt must not be
+	// reported with Fail, if it should be retried.
+	Reset(keepElapsed ...bool) error
+	// Retries provides the number of resets.
+	Retries() int
+	// SetBackoff sets the time to wait for the next attempt.
+	// It is shown by backoff decorators.
+	SetBackoff(d time.Duration)
+	// Backoff provides the remaining time to wait
+	// for the next attempt.
+	Backoff() time.Duration
+
+	// Touch records a progress of the element without
+	// changing it, for example, for spinners.
+	Touch()
+	// LastUpdate provides the time of the last progress.
+	LastUpdate() time.Time
+	// IsStalled reports whether there was no progress
+	// for the configured stall timeout.
+	IsStalled() bool
+	// TimeStalled provides the time since the last progress
+	// of a stalled element, or 0 if it is not stalled.
+	TimeStalled() time.Duration
 }
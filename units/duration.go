@@ -0,0 +1,66 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// DurationFormat formats a duration.
+type DurationFormat = func(d time.Duration) string
+
+// Clock formats a duration as hh:mm:ss.
+func Clock(d time.Duration) string {
+	s := int(d.Truncate(time.Second) / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", s/3600, s/60%60, s%60)
+}
+
+// Compact formats a duration in a compact human readable
+// style, like 45s, 1m05s or 2h03m10s.
+func Compact(d time.Duration) string {
+	s := int(d.Truncate(time.Second) / time.Second)
+	return compact(s, fmt.Sprintf("%02ds", s%60))
+}
+
+// Precise provides a DurationFormat showing the seconds with the given
+// number of decimals, like 1.25s or 1m05.3s. It is intended for
+// short actions.
+func Precise(decimals int) DurationFormat {
+	decimals = max(decimals, 0)
+	return func(d time.Duration) string {
+		s := int(d / time.Second)
+		// truncate to avoid rounding up to 60s
+		e := math.Pow(10, float64(decimals))
+		frac := math.Trunc((d%time.Minute).Seconds()*e) / e
+		width := 2
+		if decimals > 0 {
+			width += decimals + 1
+		}
+		return compact(s, fmt.Sprintf("%0*.*fs", width, decimals, frac))
+	}
+}
+
+func compact(s int, seconds string) string {
+	h, m := s/3600, s/60%60
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%dh%02dm%s", h, m, seconds)
+	case m > 0:
+		return fmt.Sprintf("%dm%s", m, seconds)
+	default:
+		return strings.TrimPrefix(seconds, "0")
+	}
+}
+
+// FixedWidth provides a DurationFormat padding the
+// output of the given format on the left to the given width.
+func FixedWidth(f DurationFormat, width int) DurationFormat {
+	return func(d time.Duration) string {
+		s := f(d)
+		if len(s) < width {
+			s = strings.Repeat(" ", width-len(s)) + s
+		}
+		return s
+	}
+}
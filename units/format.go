@@ -0,0 +1,163 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Step is a unit of a Ladder.
+type Step struct {
+	// Label is the label shown for the unit.
+	Label string
+	// Size is the size of the unit in base units.
+	Size float64
+}
+
+// Ladder is a sequence of units ascending by size.
+// The first step is used for values smaller than
+// the size of the second one.
+type Ladder []Step
+
+// NewLadder provides a Ladder for the given labels with
+// a constant factor between two consecutive units.
+// The first label is used for the base unit.
+func NewLadder(factor float64, labels ...string) Ladder {
+	var l Ladder
+	size := 1.0
+	for _, u := range labels {
+		l = append(l, Step{u, size})
+		size *= factor
+	}
+	return l
+}
+
+var (
+	// IEC is the Ladder for bytes with binary prefixes (KiB = 1024 B).
+	IEC = NewLadder(1024, "B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB")
+	// SI is the Ladder for bytes with decimal prefixes (kB = 1000 B).
+	SI = NewLadder(1000, "B", "kB", "MB", "GB", "TB", "PB", "EB")
+	// Metric is the Ladder for plain amounts with decimal prefixes.
+	Metric = NewLadder(1000, "", "k", "M", "G", "T", "P", "E")
+)
+
+// Formatter formats values according to a Ladder.
+// Its method Format can be used as Unit, for example,
+// for the Amount and Processed decorators.
+//
+//	ttyprogress.Amount(units.IECBytes().SetPrecision(1).Format)
+type Formatter struct {
+	ladder    Ladder
+	precision int
+	width     int
+	scale     float64
+	rate      bool
+}
+
+// NewFormatter provides a Formatter for a user-defined Ladder.
+func NewFormatter(l Ladder) *Formatter {
+	return &Formatter{ladder: l, scale: 1}
+}
+
+// IECBytes provides a Formatter for bytes using binary prefixes.
+func IECBytes() *Formatter {
+	return NewFormatter(IEC)
+}
+
+// SIBytes provides a Formatter for bytes using decimal prefixes.
+func SIBytes() *Formatter {
+	return NewFormatter(SI)
+}
+
+// Amounts provides a Formatter for plain amounts using decimal prefixes.
+func Amounts() *Formatter {
+	return NewFormatter(Metric)
+}
+
+// SetPrecision sets the number of decimals shown for
+// values not given in the base unit. The default is 0.
+func (f *Formatter) SetPrecision(n int) *Formatter {
+	f.precision = max(n, 0)
+	return f
+}
+
+// SetWidth sets a minimal width. Shorter results are padded
+// on the left to avoid jitter of changing values.
+func (f *Formatter) SetWidth(n int) *Formatter {
+	f.width = n
+	return f
+}
+
+// SetScale sets the size of a value in base units,
+// for example 1024 if the values are given in KiB.
+func (f *Formatter) SetScale(s float64) *Formatter {
+	f.scale = s
+	return f
+}
+
+// SetPerSecond marks the values as rates per second.
+func (f *Formatter) SetPerSecond(b ...bool) *Formatter {
+	f.rate = len(b) == 0 || b[0]
+	return f
+}
+
+// Format formats an integer value.
+func (f *Formatter) Format(n int) string {
+	return f.FormatFloat(float64(n))
+}
+
+// FormatFloat formats a value using the largest unit
+// not exceeding the value.
+func (f *Formatter) FormatFloat(v float64) string {
+	v *= f.scale
+
+	var s string
+	if len(f.ladder) == 0 {
+		s = f.format(v, 0)
+	} else {
+		i := f.step(math.Abs(v))
+		step := f.ladder[i]
+		s = f.format(v/step.Size, f.stepPrecision(i))
+		if step.Label != "" {
+			s += " " + step.Label
+		}
+	}
+	if f.rate {
+		s += "/s"
+	}
+	if len(s) < f.width {
+		s = strings.Repeat(" ", f.width-len(s)) + s
+	}
+	return s
+}
+
+// step determines the index of the largest unit not
+// exceeding the value after rounding to the precision.
+func (f *Formatter) step(v float64) int {
+	i := 0
+	for i+1 < len(f.ladder) && v >= f.ladder[i+1].Size {
+		i++
+	}
+	if i+1 < len(f.ladder) {
+		// rounding may reach the next unit (1023.96 KiB -> 1024.0 KiB).
+		e := math.Pow(10, float64(f.stepPrecision(i)))
+		if math.Round(v/f.ladder[i].Size*e)/e*f.ladder[i].Size >= f.ladder[i+1].Size {
+			i++
+		}
+	}
+	return i
+}
+
+// stepPrecision provides the precision used to print
+// a value in the unit with the given index.
+func (f *Formatter) stepPrecision(i int) int {
+	if i == 0 && f.ladder[i].Size == 1 {
+		// base units are not split
+		return 0
+	}
+	return f.precision
+}
+
+func (f *Formatter) format(v float64, precision int) string {
+	return fmt.Sprintf("%.*f", precision, v)
+}
@@ -44,6 +44,9 @@ const TB = 1024 * GB
 const PB = 1024 * TB
 const EB = 1024 * PB
 
+// Bytes provides a Unit for bytes shown in integral units
+// with a factor of 1024. Use IECBytes or SIBytes for
+// standard prefixes and decimals.
 func Bytes(scale ...int64) Unit {
 	return func(n int) string {
 		return Scaled(n, 1024, byteUnits, scale...)
@@ -74,12 +77,12 @@ func Seconds(n int) string {
 
 	h, m := m/60, m%60
 	if h == 0 {
-		return fmt.Sprintf("%d:%d", m, s)
+		return fmt.Sprintf("%d:%02d", m, s)
 	}
 
 	d, h := h/24, h%24
 	if d == 0 {
-		return fmt.Sprintf("%d:%d:%d", h, m, s)
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
 	}
-	return fmt.Sprintf("%d days %d:%d:%d", d, h, m, s)
+	return fmt.Sprintf("%d days %d:%02d:%02d", d, h, m, s)
 }
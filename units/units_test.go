@@ -1,6 +1,8 @@
 package units_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -38,4 +40,99 @@ var _ = Describe("Units Test Environment", func() {
 			Expect(u(999 * 1000 * 1000 * 1000)).To(Equal("999000 km"))
 		})
 	})
+
+	Context("seconds", func() {
+		It("pads minutes and seconds", func() {
+			Expect(units.Seconds(5)).To(Equal("5s"))
+			Expect(units.Seconds(65)).To(Equal("1:05"))
+			Expect(units.Seconds(3605)).To(Equal("1:00:05"))
+			Expect(units.Seconds(90061)).To(Equal("1 days 1:01:01"))
+		})
+	})
+
+	Context("durations", func() {
+		It("clock", func() {
+			Expect(units.Clock(0)).To(Equal("00:00:00"))
+			Expect(units.Clock(65 * time.Second)).To(Equal("00:01:05"))
+			Expect(units.Clock(26*time.Hour + 500*time.Millisecond)).To(Equal("26:00:00"))
+		})
+
+		It("compact", func() {
+			Expect(units.Compact(0)).To(Equal("0s"))
+			Expect(units.Compact(45 * time.Second)).To(Equal("45s"))
+			Expect(units.Compact(65 * time.Second)).To(Equal("1m05s"))
+			Expect(units.Compact(2*time.Hour + 3*time.Minute + 10*time.Second)).To(Equal("2h03m10s"))
+		})
+
+		It("precise", func() {
+			f := units.Precise(2)
+			Expect(f(1250 * time.Millisecond)).To(Equal("1.25s"))
+			Expect(f(59999 * time.Millisecond)).To(Equal("59.99s"))
+			Expect(f(65300 * time.Millisecond)).To(Equal("1m05.30s"))
+			Expect(units.Precise(0)(1900 * time.Millisecond)).To(Equal("1s"))
+		})
+
+		It("fixed width", func() {
+			f := units.FixedWidth(units.Compact, 6)
+			Expect(f(5 * time.Second)).To(Equal("    5s"))
+			Expect(f(65 * time.Second)).To(Equal(" 1m05s"))
+		})
+	})
+
+	Context("formatter", func() {
+		It("iec", func() {
+			u := units.IECBytes().SetPrecision(1).Format
+			Expect(u(1)).To(Equal("1 B"))
+			Expect(u(1023)).To(Equal("1023 B"))
+			Expect(u(1536)).To(Equal("1.5 KiB"))
+			Expect(u(3 * units.MB / 2)).To(Equal("1.5 MiB"))
+			Expect(u(units.MB - 1)).To(Equal("1.0 MiB"))
+		})
+
+		It("rounds base units to the next unit", func() {
+			Expect(units.IECBytes().SetPrecision(1).FormatFloat(1023.6)).To(Equal("1.0 KiB"))
+			Expect(units.IECBytes().SetPrecision(1).FormatFloat(1023.4)).To(Equal("1023 B"))
+			Expect(units.SIBytes().SetPrecision(2).FormatFloat(999.7)).To(Equal("1.00 kB"))
+			Expect(units.SIBytes().SetPrecision(2).FormatFloat(999.4)).To(Equal("999 B"))
+		})
+
+		It("si", func() {
+			u := units.SIBytes().SetPrecision(2).Format
+			Expect(u(999)).To(Equal("999 B"))
+			Expect(u(1536)).To(Equal("1.54 kB"))
+			Expect(u(2000000)).To(Equal("2.00 MB"))
+		})
+
+		It("integral", func() {
+			u := units.IECBytes().Format
+			Expect(u(1536)).To(Equal("2 KiB"))
+			Expect(u(1280)).To(Equal("1 KiB"))
+		})
+
+		It("scaled", func() {
+			u := units.IECBytes().SetScale(1024).SetPrecision(1).Format
+			Expect(u(1)).To(Equal("1.0 KiB"))
+			Expect(u(1536)).To(Equal("1.5 MiB"))
+		})
+
+		It("fixed width", func() {
+			u := units.Amounts().SetPrecision(1).SetWidth(7).Format
+			Expect(u(5)).To(Equal("      5"))
+			Expect(u(1500)).To(Equal("  1.5 k"))
+		})
+
+		It("rate", func() {
+			u := units.IECBytes().SetPrecision(1).SetPerSecond().Format
+			Expect(u(2048)).To(Equal("2.0 KiB/s"))
+			Expect(u(10)).To(Equal("10 B/s"))
+		})
+
+		It("custom ladder", func() {
+			l := units.Ladder{{"s", 1}, {"min", 60}, {"h", 3600}}
+			u := units.NewFormatter(l).SetPrecision(1).Format
+			Expect(u(30)).To(Equal("30 s"))
+			Expect(u(90)).To(Equal("1.5 min"))
+			Expect(u(5400)).To(Equal("1.5 h"))
+		})
+	})
 })
@@ -44,6 +44,21 @@ func Processed(unit ...units.Unit) DecoratorFunc {
 	}
 }
 
+// Rate is a decorator for Bar elements providing the average
+// progress per second since the element has been started.
+// It is typically used with a Unit formatting rates,
+// like units.IECBytes().SetPerSecond().Format.
+func Rate(unit ...units.Unit) DecoratorFunc {
+	u := general.OptionalDefaulted(units.Plain, unit...)
+	return func(e ElementState) any {
+		elapsed := e.TimeElapsed().Seconds()
+		if !e.IsStarted() || elapsed <= 0 {
+			return ""
+		}
+		return u(int(float64(e.(interface{ Current() int }).Current()) / elapsed))
+	}
+}
+
 // PercentTerminalSize return a width relative to to the terminal size.
 func PercentTerminalSize(p uint) uint {
 	x, _ := blocks.GetTerminalSize()
@@ -0,0 +1,47 @@
+package ttyprogress_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// screen replays the output of a progress context
+// and provides the actually visible lines.
+func screen(out string) []string {
+	var lines []string
+	for len(out) > 0 {
+		if strings.HasPrefix(out, "\x1b[1A") {
+			lines = lines[:len(lines)-1]
+			out = out[4:]
+			continue
+		}
+		if strings.HasPrefix(out, "\x1b[2K") {
+			out = out[4:]
+			continue
+		}
+		i := strings.Index(out, "\n")
+		lines = append(lines, out[:i])
+		out = out[i+1:]
+	}
+	return lines
+}
+
+// syncBuffer is a buffer, which can be read
+// while the progress context is writing.
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(data []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(data)
+}
+
+func (b *syncBuffer) Screen() []string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return screen(b.buf.String())
+}